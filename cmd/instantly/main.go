@@ -9,10 +9,7 @@ import (
 var exit = os.Exit
 
 func run() int {
-	if err := cmd.Execute(); err != nil {
-		return 1
-	}
-	return 0
+	return cmd.ExitCode(cmd.Execute())
 }
 
 func main() {
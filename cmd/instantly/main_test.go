@@ -20,8 +20,14 @@ func TestRun_Error(t *testing.T) {
 	t.Cleanup(func() { os.Args = oldArgs })
 	os.Args = []string{"instantly", "version", "--output", "nope"}
 
-	if code := run(); code != 1 {
-		t.Fatalf("code = %d, want 1", code)
+	// Usage errors exit 2.
+	if code := run(); code != 2 {
+		t.Fatalf("code = %d, want 2", code)
+	}
+
+	os.Args = []string{"instantly", "bogus"}
+	if code := run(); code != 2 {
+		t.Fatalf("unknown command: code = %d, want 2", code)
 	}
 }
 
@@ -37,7 +43,7 @@ func TestMain_UsesExit(t *testing.T) {
 	os.Args = []string{"instantly", "version", "--output", "nope"}
 
 	main()
-	if got != 1 {
-		t.Fatalf("exit code = %d, want 1", got)
+	if got != 2 {
+		t.Fatalf("exit code = %d, want 2", got)
 	}
 }
@@ -1,14 +1,17 @@
 module github.com/salmonumbrella/instantly-cli
 
-go 1.25
+go 1.25.0
 
 require (
 	github.com/itchyny/gojq v0.12.18
 	github.com/spf13/cobra v1.10.2
 	github.com/spf13/pflag v1.0.10
+	go.yaml.in/yaml/v3 v3.0.4
+	golang.org/x/term v0.45.0
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/itchyny/timefmt-go v0.1.7 // indirect
+	golang.org/x/sys v0.47.0 // indirect
 )
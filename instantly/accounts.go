@@ -0,0 +1,69 @@
+package instantly
+
+import (
+	"context"
+	"iter"
+	"strconv"
+)
+
+// Account is a sending email account.
+type Account struct {
+	raw
+	Email            string `json:"email"`
+	FirstName        string `json:"first_name"`
+	LastName         string `json:"last_name"`
+	Status           int    `json:"status"`
+	SetupPending     bool   `json:"setup_pending"`
+	WarmupStatus     int    `json:"warmup_status"`
+	ProviderCode     int    `json:"provider_code"`
+	DailyLimit       int    `json:"daily_limit"`
+	SendingGap       int    `json:"sending_gap"`
+	TimestampCreated string `json:"timestamp_created"`
+	TimestampUpdated string `json:"timestamp_updated"`
+}
+
+// ReadyToSend reports whether the account can be used as a campaign sender:
+// active, setup complete and warmup complete.
+func (a Account) ReadyToSend() bool {
+	return a.Status == 1 && !a.SetupPending && a.WarmupStatus == 1 && a.Email != ""
+}
+
+// ListAccountsParams filters GET /accounts.
+type ListAccountsParams struct {
+	ListParams
+	// Status filters by account status (1 active, 2 paused, negative error codes).
+	Status *int
+	// ProviderCode filters by provider (1 IMAP, 2 Google, 3 Microsoft, 4 AWS).
+	ProviderCode *int
+}
+
+// AccountsService covers /accounts.
+type AccountsService struct {
+	t *Transport
+}
+
+// List fetches one page of accounts.
+func (s *AccountsService) List(ctx context.Context, p ListAccountsParams) (*Page[Account], *Meta, error) {
+	q := p.values()
+	if p.Status != nil {
+		q.Set("status", strconv.Itoa(*p.Status))
+	}
+	if p.ProviderCode != nil {
+		q.Set("provider_code", strconv.Itoa(*p.ProviderCode))
+	}
+	return getPage[Account](ctx, s.t, "/accounts", mergeQuery(q, p.Query))
+}
+
+// All iterates over every account, following cursors.
+func (s *AccountsService) All(ctx context.Context, p ListAccountsParams) iter.Seq2[Account, error] {
+	return iterate(ctx, p.StartingAfter, func(ctx context.Context, cursor string) (*Page[Account], error) {
+		p.StartingAfter = cursor
+		page, _, err := s.List(ctx, p)
+		return page, err
+	})
+}
+
+// Get fetches an account by email.
+func (s *AccountsService) Get(ctx context.Context, email string) (*Account, *Meta, error) {
+	return getObject[Account](ctx, s.t, pathID("/accounts", email), nil)
+}
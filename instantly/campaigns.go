@@ -0,0 +1,145 @@
+package instantly
+
+import (
+	"context"
+	"iter"
+)
+
+// Campaign is an Instantly campaign.
+type Campaign struct {
+	raw
+	ID               string             `json:"id"`
+	Name             string             `json:"name"`
+	Status           int                `json:"status"`
+	DailyLimit       int                `json:"daily_limit"`
+	EmailGap         int                `json:"email_gap"`
+	EmailList        []string           `json:"email_list"`
+	OpenTracking     bool               `json:"open_tracking"`
+	LinkTracking     bool               `json:"link_tracking"`
+	StopOnReply      bool               `json:"stop_on_reply"`
+	StopOnAutoReply  bool               `json:"stop_on_auto_reply"`
+	Sequences        []CampaignSequence `json:"sequences"`
+	CampaignSchedule *CampaignSchedule  `json:"campaign_schedule"`
+	Organization     string             `json:"organization"`
+	TimestampCreated string             `json:"timestamp_created"`
+	TimestampUpdated string             `json:"timestamp_updated"`
+}
+
+// CampaignSequence is an ordered list of steps.
+type CampaignSequence struct {
+	Steps []CampaignStep `json:"steps"`
+}
+
+// CampaignStep is one email in a sequence; Delay is in days after the previous step.
+type CampaignStep struct {
+	Type     string            `json:"type"`
+	Delay    int               `json:"delay"`
+	Variants []CampaignVariant `json:"variants"`
+}
+
+// CampaignVariant is one A/B variant of a step.
+type CampaignVariant struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// CampaignSchedule holds the sending windows for a campaign.
+type CampaignSchedule struct {
+	Schedules []Schedule `json:"schedules"`
+}
+
+// Schedule is a named sending window. Days is keyed "0" (Sunday) through "6".
+type Schedule struct {
+	Name     string          `json:"name"`
+	Timezone string          `json:"timezone"`
+	Timing   ScheduleTiming  `json:"timing"`
+	Days     map[string]bool `json:"days"`
+}
+
+// ScheduleTiming is a daily HH:MM window.
+type ScheduleTiming struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ListCampaignsParams filters GET /campaigns.
+type ListCampaignsParams struct {
+	ListParams
+}
+
+// CreateCampaignRequest is the body for POST /campaigns.
+type CreateCampaignRequest struct {
+	Name             string             `json:"name"`
+	Sequences        []CampaignSequence `json:"sequences"`
+	EmailList        []string           `json:"email_list"`
+	OpenTracking     bool               `json:"open_tracking"`
+	LinkTracking     bool               `json:"link_tracking"`
+	DailyLimit       int                `json:"daily_limit"`
+	EmailGap         int                `json:"email_gap"`
+	StopOnReply      bool               `json:"stop_on_reply"`
+	StopOnAutoReply  bool               `json:"stop_on_auto_reply"`
+	CampaignSchedule CampaignSchedule   `json:"campaign_schedule"`
+}
+
+// UpdateCampaignRequest is the body for PATCH /campaigns/{id}. Nil fields are left unchanged.
+type UpdateCampaignRequest struct {
+	Name         *string `json:"name,omitempty"`
+	DailyLimit   *int    `json:"daily_limit,omitempty"`
+	EmailGap     *int    `json:"email_gap,omitempty"`
+	OpenTracking *bool   `json:"open_tracking,omitempty"`
+	LinkTracking *bool   `json:"link_tracking,omitempty"`
+}
+
+// Empty reports whether the update would change nothing.
+func (r UpdateCampaignRequest) Empty() bool {
+	return r.Name == nil && r.DailyLimit == nil && r.EmailGap == nil && r.OpenTracking == nil && r.LinkTracking == nil
+}
+
+// CampaignsService covers /campaigns.
+type CampaignsService struct {
+	t *Transport
+}
+
+// List fetches one page of campaigns.
+func (s *CampaignsService) List(ctx context.Context, p ListCampaignsParams) (*Page[Campaign], *Meta, error) {
+	return getPage[Campaign](ctx, s.t, "/campaigns", mergeQuery(p.values(), p.Query))
+}
+
+// All iterates over every campaign, following cursors.
+func (s *CampaignsService) All(ctx context.Context, p ListCampaignsParams) iter.Seq2[Campaign, error] {
+	return iterate(ctx, p.StartingAfter, func(ctx context.Context, cursor string) (*Page[Campaign], error) {
+		p.StartingAfter = cursor
+		page, _, err := s.List(ctx, p)
+		return page, err
+	})
+}
+
+// Get fetches a campaign by ID.
+func (s *CampaignsService) Get(ctx context.Context, id string) (*Campaign, *Meta, error) {
+	return getObject[Campaign](ctx, s.t, pathID("/campaigns", id), nil)
+}
+
+// Create creates a campaign.
+func (s *CampaignsService) Create(ctx context.Context, req CreateCampaignRequest) (*Campaign, *Meta, error) {
+	return postObject[Campaign](ctx, s.t, "/campaigns", req)
+}
+
+// Update patches campaign settings.
+func (s *CampaignsService) Update(ctx context.Context, id string, req UpdateCampaignRequest) (*Campaign, *Meta, error) {
+	return patchObject[Campaign](ctx, s.t, pathID("/campaigns", id), req)
+}
+
+// Activate starts (or resumes) sending.
+func (s *CampaignsService) Activate(ctx context.Context, id string) (*Campaign, *Meta, error) {
+	return postObject[Campaign](ctx, s.t, pathID("/campaigns", id)+"/activate", nil)
+}
+
+// Pause stops sending.
+func (s *CampaignsService) Pause(ctx context.Context, id string) (*Campaign, *Meta, error) {
+	return postObject[Campaign](ctx, s.t, pathID("/campaigns", id)+"/pause", nil)
+}
+
+// Delete deletes a campaign and returns it as the API echoed it.
+func (s *CampaignsService) Delete(ctx context.Context, id string) (*Campaign, *Meta, error) {
+	return deleteObject[Campaign](ctx, s.t, pathID("/campaigns", id))
+}
@@ -0,0 +1,180 @@
+// Package instantly is a typed Go client for the Instantly.ai v2 API.
+//
+// It is the same client the instantly CLI is built on: typed resources and
+// request/response methods per endpoint, cursor pagination iterators, and the
+// retry/rate-limit behavior of the underlying transport.
+//
+//	c := instantly.New(os.Getenv("INSTANTLY_API_KEY"))
+//	for campaign, err := range c.Campaigns.All(ctx, instantly.ListCampaignsParams{}) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(campaign.ID, campaign.Name)
+//	}
+package instantly
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/salmonumbrella/instantly-cli/internal/api"
+	"github.com/salmonumbrella/instantly-cli/internal/sdkbridge"
+)
+
+// DefaultBaseURL is the production Instantly v2 API root.
+const DefaultBaseURL = api.DefaultBaseURL
+
+// Transport is the untyped HTTP client every service is built on. Use it for
+// endpoints that do not (yet) have a typed method.
+type Transport struct {
+	c *api.Client
+}
+
+// GetJSON sends a GET and returns the decoded body (numbers are json.Number).
+func (t *Transport) GetJSON(ctx context.Context, path string, query url.Values) (any, *Meta, error) {
+	return t.c.GetJSON(ctx, path, query)
+}
+
+// PostJSON sends payload as a JSON POST and returns the decoded body.
+func (t *Transport) PostJSON(ctx context.Context, path string, query url.Values, payload any) (any, *Meta, error) {
+	return t.c.PostJSON(ctx, path, query, payload)
+}
+
+// PatchJSON sends payload as a JSON PATCH and returns the decoded body.
+func (t *Transport) PatchJSON(ctx context.Context, path string, query url.Values, payload any) (any, *Meta, error) {
+	return t.c.PatchJSON(ctx, path, query, payload)
+}
+
+// DeleteJSON sends a DELETE and returns the decoded body.
+func (t *Transport) DeleteJSON(ctx context.Context, path string, query url.Values) (any, *Meta, error) {
+	return t.c.DeleteJSON(ctx, path, query)
+}
+
+func init() {
+	sdkbridge.NewClient = func(c *api.Client) any { return newClient(&Transport{c: c}) }
+}
+
+// Meta describes the HTTP request behind a response (URL, rate limit info).
+type Meta = api.Meta
+
+// RateLimitInfo is the parsed x-ratelimit-* header set.
+type RateLimitInfo = api.RateLimitInfo
+
+// APIError is returned for non-2xx responses. Use errors.As to inspect it.
+type APIError = api.APIError
+
+// Middleware wraps the HTTP transport (logging, auditing, extra headers).
+type Middleware = api.Middleware
+
+// Client is a typed Instantly API client.
+type Client struct {
+	transport *Transport
+
+	Accounts       *AccountsService
+	Campaigns      *CampaignsService
+	Leads          *LeadsService
+	LeadLists      *LeadListsService
+	Emails         *EmailsService
+	Webhooks       *WebhooksService
+	Tags           *TagsService
+	BackgroundJobs *BackgroundJobsService
+}
+
+// Option configures a Client created with New.
+type Option func(*Transport)
+
+// WithBaseURL overrides the API root (useful for tests and proxies).
+func WithBaseURL(baseURL string) Option {
+	return func(t *Transport) {
+		if baseURL != "" {
+			t.c.BaseURL = baseURL
+		}
+	}
+}
+
+// WithTimeout sets the per-request HTTP timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(t *Transport) {
+		if d > 0 {
+			t.c.HTTPClient.Timeout = d
+		}
+	}
+}
+
+// WithRetries enables retries for 429 and transient 5xx responses.
+// Writes are only retried when an idempotency key is set.
+func WithRetries(max429, max5xx int) Option {
+	return func(t *Transport) {
+		t.c.Max429Retries = max429
+		t.c.Max5xxRetries = max5xx
+	}
+}
+
+// WithIdempotencyKey sends an Idempotency-Key header on every request.
+func WithIdempotencyKey(key string) Option {
+	return func(t *Transport) { t.c.IdempotencyKey = key }
+}
+
+// WithRateLimit paces requests from the x-ratelimit-* response headers so the
+// client slows down before the quota runs out. SDK clients do not pace unless
+// this or WithSharedRateLimit is given; the instantly CLI paces by default.
+func WithRateLimit() Option {
+	return func(t *Transport) { t.c.RateLimiter = api.NewRateLimiter("") }
+}
+
+// WithSharedRateLimit is WithRateLimit with pacing state persisted at path, so
+// parallel processes using the same API key share one budget.
+func WithSharedRateLimit(path string) Option {
+	return func(t *Transport) { t.c.RateLimiter = api.NewRateLimiter(path) }
+}
+
+// WithMiddleware adds transport middleware; the first one is the outermost.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(t *Transport) { t.c.Use(mw...) }
+}
+
+// WithDryRun makes every request return a description of itself instead of
+// being sent.
+func WithDryRun() Option {
+	return func(t *Transport) { t.c.DryRun = true }
+}
+
+// WithHTTPClient replaces the underlying *http.Client (e.g. for a custom
+// proxy or TLS config). Middleware still wraps its transport.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(t *Transport) {
+		if hc != nil {
+			t.c.HTTPClient = hc
+		}
+	}
+}
+
+// New creates a Client for the given API key.
+func New(apiKey string, opts ...Option) *Client {
+	t := &Transport{c: api.NewClient(DefaultBaseURL, apiKey, 0)}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return newClient(t)
+}
+
+func newClient(t *Transport) *Client {
+	c := &Client{transport: t}
+	c.Accounts = &AccountsService{t: t}
+	c.Campaigns = &CampaignsService{t: t}
+	c.Leads = &LeadsService{t: t}
+	c.LeadLists = &LeadListsService{t: t}
+	c.Emails = &EmailsService{t: t}
+	c.Webhooks = &WebhooksService{t: t}
+	c.Tags = &TagsService{t: t}
+	c.BackgroundJobs = &BackgroundJobsService{t: t}
+	return c
+}
+
+// Transport returns the underlying untyped client.
+func (c *Client) Transport() *Transport { return c.transport }
+
+// Ptr returns a pointer to v, for optional request fields.
+func Ptr[T any](v T) *T { return &v }
@@ -0,0 +1,221 @@
+package instantly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return New("k", WithBaseURL(srv.URL))
+}
+
+func TestCampaigns_GetKeepsRaw(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/campaigns/c 1" || r.Header.Get("Authorization") != "Bearer k" {
+			t.Fatalf("path=%q auth=%q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		_, _ = w.Write([]byte(`{"id":"c 1","name":"Q1","status":1,"daily_limit":30,"not_modeled":"x"}`))
+	})
+
+	got, meta, err := c.Campaigns.Get(context.Background(), "c 1")
+	if err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if got.ID != "c 1" || got.Name != "Q1" || got.Status != 1 || got.DailyLimit != 30 {
+		t.Fatalf("got=%#v", got)
+	}
+	if got.Raw["not_modeled"] != "x" {
+		t.Fatalf("raw=%#v", got.Raw)
+	}
+	if meta == nil || meta.Request.Method != http.MethodGet {
+		t.Fatalf("meta=%#v", meta)
+	}
+}
+
+func TestAccounts_ListParamsAndReadyToSend(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("limit") != "5" || q.Get("status") != "1" || q.Get("provider_code") != "2" || q.Get("x") != "y" {
+			t.Fatalf("query=%v", q)
+		}
+		_, _ = w.Write([]byte(`{"items":[
+			null,
+			{"email":"a@x","status":1,"setup_pending":false,"warmup_status":1},
+			{"email":"b@x","status":1,"setup_pending":true,"warmup_status":1}
+		],"next_starting_after":"b@x"}`))
+	})
+
+	page, _, err := c.Accounts.List(context.Background(), ListAccountsParams{
+		ListParams:   ListParams{Limit: 5, Query: map[string][]string{"x": {"y"}}},
+		Status:       Ptr(1),
+		ProviderCode: Ptr(2),
+	})
+	if err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if len(page.Items) != 3 || page.NextStartingAfter != "b@x" {
+		t.Fatalf("page=%#v", page)
+	}
+	if page.Items[0].ReadyToSend() || !page.Items[1].ReadyToSend() || page.Items[2].ReadyToSend() {
+		t.Fatalf("ready flags wrong: %#v", page.Items)
+	}
+}
+
+func TestLeads_AllFollowsBodyCursor(t *testing.T) {
+	var calls int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body["campaign"] != "cid" || body["extra"] != true {
+			t.Fatalf("body=%#v", body)
+		}
+		switch body["starting_after"] {
+		case nil:
+			_, _ = w.Write([]byte(`{"items":[{"id":"1"},{"id":"2"}],"next_starting_after":"2"}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"items":[{"id":"3"}],"pagination":{"next_starting_after":"2"}}`))
+		default:
+			t.Fatalf("unexpected cursor %v", body["starting_after"])
+		}
+	})
+
+	var ids []string
+	for lead, err := range c.Leads.All(context.Background(), ListLeadsParams{Campaign: "cid", Extra: map[string]any{"extra": true}}) {
+		if err != nil {
+			t.Fatalf("err=%v", err)
+		}
+		ids = append(ids, lead.ID)
+	}
+	// The second page echoes its own cursor; iteration must stop instead of looping.
+	if len(ids) != 3 || ids[2] != "3" || calls != 2 {
+		t.Fatalf("ids=%v calls=%d", ids, calls)
+	}
+
+	// Breaking out early stops fetching.
+	calls = 0
+	for range c.Leads.All(context.Background(), ListLeadsParams{Campaign: "cid", Extra: map[string]any{"extra": true}}) {
+		break
+	}
+	if calls != 1 {
+		t.Fatalf("calls=%d", calls)
+	}
+}
+
+func TestCampaigns_CreateAndUpdateBodies(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		switch r.Method {
+		case http.MethodPost:
+			if body["name"] != "n" || body["stop_on_reply"] != true {
+				t.Fatalf("create body=%#v", body)
+			}
+		case http.MethodPatch:
+			// Nil fields must be omitted so they stay unchanged server-side.
+			if len(body) != 1 || body["daily_limit"] != float64(0) {
+				t.Fatalf("update body=%#v", body)
+			}
+		}
+		_, _ = w.Write([]byte(`{"id":"cid"}`))
+	})
+
+	ctx := context.Background()
+	if _, _, err := c.Campaigns.Create(ctx, CreateCampaignRequest{Name: "n", StopOnReply: true}); err != nil {
+		t.Fatalf("create err=%v", err)
+	}
+	req := UpdateCampaignRequest{DailyLimit: Ptr(0)}
+	if req.Empty() || !(UpdateCampaignRequest{}).Empty() {
+		t.Fatalf("Empty() wrong")
+	}
+	if _, _, err := c.Campaigns.Update(ctx, "cid", req); err != nil {
+		t.Fatalf("update err=%v", err)
+	}
+}
+
+func TestClient_ErrorsAndShapes(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/background-jobs/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+		case "/background-jobs":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			_, _ = w.Write([]byte(`{"items":[{"id":1}]}`))
+		}
+	})
+	ctx := context.Background()
+
+	_, _, err := c.BackgroundJobs.Get(ctx, "missing")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound {
+		t.Fatalf("err=%v", err)
+	}
+
+	if _, _, err := c.BackgroundJobs.List(ctx, ListBackgroundJobsParams{}); err == nil {
+		t.Fatalf("expected shape error for bare array")
+	}
+
+	// id is modeled as a string; a numeric id leaves ID empty but stays in Raw.
+	tags, _, err := c.Tags.List(ctx, ListTagsParams{})
+	if err != nil || len(tags.Items) != 1 || tags.Items[0].ID != "" || tags.Items[0].Raw["id"] == nil {
+		t.Fatalf("tags=%#v err=%v", tags, err)
+	}
+
+	var seenErr error
+	for _, err := range c.BackgroundJobs.All(ctx, ListBackgroundJobsParams{}) {
+		seenErr = err
+	}
+	if seenErr == nil {
+		t.Fatalf("expected iterator error")
+	}
+}
+
+func TestClient_DryRunPassesThrough(t *testing.T) {
+	c := New("", WithBaseURL("http://example.invalid"), WithDryRun())
+
+	job, _, err := c.BackgroundJobs.Get(context.Background(), "j1")
+	if err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if job.Raw["dry_run"] != true {
+		t.Fatalf("raw=%#v", job.Raw)
+	}
+}
+
+func TestCampaigns_ListToleratesFieldTypeDrift(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":[
+			{"id":"c1","name":"ok","status":1},
+			{"id":"c2","name":"drift","status":"active","daily_limit":12.5,"sequences":[{"steps":[{"delay":null}]}]},
+			"not-an-object"
+		]}`))
+	})
+
+	page, _, err := c.Campaigns.List(context.Background(), ListCampaignsParams{})
+	if err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if len(page.Items) != 3 || page.Items[0].Status != 1 {
+		t.Fatalf("items=%#v", page.Items)
+	}
+	drift := page.Items[1]
+	if drift.ID != "c2" || drift.Name != "drift" || drift.Status != 0 || drift.Raw["status"] != "active" {
+		t.Fatalf("drift=%#v", drift)
+	}
+	if len(page.Raw["items"].([]any)) != 3 {
+		t.Fatalf("raw=%#v", page.Raw)
+	}
+}
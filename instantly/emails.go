@@ -0,0 +1,69 @@
+package instantly
+
+import (
+	"context"
+	"iter"
+	"strconv"
+)
+
+// Email is a sent or received message in the Unibox.
+type Email struct {
+	raw
+	ID               string     `json:"id"`
+	Subject          string     `json:"subject"`
+	FromAddressEmail string     `json:"from_address_email"`
+	EAccount         string     `json:"eaccount"`
+	CampaignID       string     `json:"campaign_id"`
+	ThreadID         string     `json:"thread_id"`
+	Body             *EmailBody `json:"body"`
+	TimestampEmail   string     `json:"timestamp_email"`
+	TimestampCreated string     `json:"timestamp_created"`
+}
+
+// EmailBody holds the text and HTML renderings of a message.
+type EmailBody struct {
+	Text string `json:"text"`
+	HTML string `json:"html"`
+}
+
+// ListEmailsParams filters GET /emails.
+type ListEmailsParams struct {
+	ListParams
+	CampaignID string
+	EAccount   string
+	IsUnread   *bool
+}
+
+// EmailsService covers /emails.
+type EmailsService struct {
+	t *Transport
+}
+
+// List fetches one page of emails.
+func (s *EmailsService) List(ctx context.Context, p ListEmailsParams) (*Page[Email], *Meta, error) {
+	q := p.values()
+	if p.CampaignID != "" {
+		q.Set("campaign_id", p.CampaignID)
+	}
+	if p.EAccount != "" {
+		q.Set("eaccount", p.EAccount)
+	}
+	if p.IsUnread != nil {
+		q.Set("is_unread", strconv.FormatBool(*p.IsUnread))
+	}
+	return getPage[Email](ctx, s.t, "/emails", mergeQuery(q, p.Query))
+}
+
+// All iterates over every matching email, following cursors.
+func (s *EmailsService) All(ctx context.Context, p ListEmailsParams) iter.Seq2[Email, error] {
+	return iterate(ctx, p.StartingAfter, func(ctx context.Context, cursor string) (*Page[Email], error) {
+		p.StartingAfter = cursor
+		page, _, err := s.List(ctx, p)
+		return page, err
+	})
+}
+
+// Get fetches an email by ID.
+func (s *EmailsService) Get(ctx context.Context, id string) (*Email, *Meta, error) {
+	return getObject[Email](ctx, s.t, pathID("/emails", id), nil)
+}
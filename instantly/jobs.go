@@ -0,0 +1,55 @@
+package instantly
+
+import (
+	"context"
+	"iter"
+)
+
+// BackgroundJob tracks a long-running server-side operation (imports, bulk moves, ...).
+type BackgroundJob struct {
+	raw
+	ID          string         `json:"id"`
+	Type        string         `json:"type"`
+	Status      string         `json:"status"`
+	Progress    float64        `json:"progress"`
+	EntityID    string         `json:"entity_id"`
+	EntityType  string         `json:"entity_type"`
+	WorkspaceID string         `json:"workspace_id"`
+	Data        map[string]any `json:"data"`
+	CreatedAt   string         `json:"created_at"`
+	UpdatedAt   string         `json:"updated_at"`
+}
+
+// Done reports whether the job reached a terminal state.
+func (j BackgroundJob) Done() bool {
+	return j.Status == "success" || j.Status == "failed"
+}
+
+// ListBackgroundJobsParams filters GET /background-jobs.
+type ListBackgroundJobsParams struct {
+	ListParams
+}
+
+// BackgroundJobsService covers /background-jobs.
+type BackgroundJobsService struct {
+	t *Transport
+}
+
+// List fetches one page of background jobs.
+func (s *BackgroundJobsService) List(ctx context.Context, p ListBackgroundJobsParams) (*Page[BackgroundJob], *Meta, error) {
+	return getPage[BackgroundJob](ctx, s.t, "/background-jobs", mergeQuery(p.values(), p.Query))
+}
+
+// All iterates over every background job, following cursors.
+func (s *BackgroundJobsService) All(ctx context.Context, p ListBackgroundJobsParams) iter.Seq2[BackgroundJob, error] {
+	return iterate(ctx, p.StartingAfter, func(ctx context.Context, cursor string) (*Page[BackgroundJob], error) {
+		p.StartingAfter = cursor
+		page, _, err := s.List(ctx, p)
+		return page, err
+	})
+}
+
+// Get fetches a background job by ID.
+func (s *BackgroundJobsService) Get(ctx context.Context, id string) (*BackgroundJob, *Meta, error) {
+	return getObject[BackgroundJob](ctx, s.t, pathID("/background-jobs", id), nil)
+}
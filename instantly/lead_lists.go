@@ -0,0 +1,77 @@
+package instantly
+
+import (
+	"context"
+	"iter"
+)
+
+// LeadList is a named collection of leads outside any campaign.
+type LeadList struct {
+	raw
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	HasEnrichmentTask bool   `json:"has_enrichment_task"`
+	OwnedBy           string `json:"owned_by"`
+	TimestampCreated  string `json:"timestamp_created"`
+}
+
+// ListLeadListsParams filters GET /lead-lists.
+type ListLeadListsParams struct {
+	ListParams
+}
+
+// CreateLeadListRequest is the body for POST /lead-lists.
+type CreateLeadListRequest struct {
+	Name              string `json:"name"`
+	HasEnrichmentTask *bool  `json:"has_enrichment_task,omitempty"`
+}
+
+// UpdateLeadListRequest is the body for PATCH /lead-lists/{id}. Nil fields are left unchanged.
+type UpdateLeadListRequest struct {
+	Name              *string `json:"name,omitempty"`
+	HasEnrichmentTask *bool   `json:"has_enrichment_task,omitempty"`
+}
+
+// Empty reports whether the update would change nothing.
+func (r UpdateLeadListRequest) Empty() bool {
+	return r.Name == nil && r.HasEnrichmentTask == nil
+}
+
+// LeadListsService covers /lead-lists.
+type LeadListsService struct {
+	t *Transport
+}
+
+// List fetches one page of lead lists.
+func (s *LeadListsService) List(ctx context.Context, p ListLeadListsParams) (*Page[LeadList], *Meta, error) {
+	return getPage[LeadList](ctx, s.t, "/lead-lists", mergeQuery(p.values(), p.Query))
+}
+
+// All iterates over every lead list, following cursors.
+func (s *LeadListsService) All(ctx context.Context, p ListLeadListsParams) iter.Seq2[LeadList, error] {
+	return iterate(ctx, p.StartingAfter, func(ctx context.Context, cursor string) (*Page[LeadList], error) {
+		p.StartingAfter = cursor
+		page, _, err := s.List(ctx, p)
+		return page, err
+	})
+}
+
+// Get fetches a lead list by ID.
+func (s *LeadListsService) Get(ctx context.Context, id string) (*LeadList, *Meta, error) {
+	return getObject[LeadList](ctx, s.t, pathID("/lead-lists", id), nil)
+}
+
+// Create creates a lead list.
+func (s *LeadListsService) Create(ctx context.Context, req CreateLeadListRequest) (*LeadList, *Meta, error) {
+	return postObject[LeadList](ctx, s.t, "/lead-lists", req)
+}
+
+// Update patches a lead list.
+func (s *LeadListsService) Update(ctx context.Context, id string, req UpdateLeadListRequest) (*LeadList, *Meta, error) {
+	return patchObject[LeadList](ctx, s.t, pathID("/lead-lists", id), req)
+}
+
+// Delete deletes a lead list and returns it as the API echoed it.
+func (s *LeadListsService) Delete(ctx context.Context, id string) (*LeadList, *Meta, error) {
+	return deleteObject[LeadList](ctx, s.t, pathID("/lead-lists", id))
+}
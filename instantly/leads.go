@@ -0,0 +1,126 @@
+package instantly
+
+import (
+	"context"
+	"iter"
+)
+
+// Lead is a contact that can be enrolled in campaigns or lead lists.
+type Lead struct {
+	raw
+	ID               string         `json:"id"`
+	Email            string         `json:"email"`
+	FirstName        string         `json:"first_name"`
+	LastName         string         `json:"last_name"`
+	CompanyName      string         `json:"company_name"`
+	Phone            string         `json:"phone"`
+	Website          string         `json:"website"`
+	Campaign         string         `json:"campaign"`
+	Payload          map[string]any `json:"payload"`
+	TimestampCreated string         `json:"timestamp_created"`
+	TimestampUpdated string         `json:"timestamp_updated"`
+}
+
+// ListLeadsParams is the body for POST /leads/list.
+type ListLeadsParams struct {
+	Limit            int
+	StartingAfter    string
+	Campaign         string
+	ListID           string
+	Status           string
+	Search           string
+	DistinctContacts *bool
+	// Extra holds additional body fields; the typed fields above override them.
+	Extra map[string]any
+}
+
+func (p ListLeadsParams) body() map[string]any {
+	body := map[string]any{}
+	for k, v := range p.Extra {
+		body[k] = v
+	}
+	if p.Limit > 0 {
+		body["limit"] = p.Limit
+	}
+	if p.StartingAfter != "" {
+		body["starting_after"] = p.StartingAfter
+	}
+	if p.Campaign != "" {
+		body["campaign"] = p.Campaign
+	}
+	if p.ListID != "" {
+		body["list_id"] = p.ListID
+	}
+	if p.Status != "" {
+		body["status"] = p.Status
+	}
+	if p.Search != "" {
+		body["search"] = p.Search
+	}
+	if p.DistinctContacts != nil {
+		body["distinct_contacts"] = *p.DistinctContacts
+	}
+	return body
+}
+
+// CreateLeadRequest is the body for POST /leads.
+type CreateLeadRequest struct {
+	Email             string `json:"email"`
+	Campaign          string `json:"campaign,omitempty"`
+	FirstName         string `json:"first_name,omitempty"`
+	LastName          string `json:"last_name,omitempty"`
+	CompanyName       string `json:"company_name,omitempty"`
+	SkipIfInWorkspace bool   `json:"skip_if_in_workspace"`
+	SkipIfInCampaign  bool   `json:"skip_if_in_campaign"`
+}
+
+// UpdateLeadRequest is the body for PATCH /leads/{id}. Nil fields are left unchanged.
+type UpdateLeadRequest struct {
+	FirstName   *string `json:"first_name,omitempty"`
+	LastName    *string `json:"last_name,omitempty"`
+	CompanyName *string `json:"company_name,omitempty"`
+}
+
+// Empty reports whether the update would change nothing.
+func (r UpdateLeadRequest) Empty() bool {
+	return r.FirstName == nil && r.LastName == nil && r.CompanyName == nil
+}
+
+// LeadsService covers /leads.
+type LeadsService struct {
+	t *Transport
+}
+
+// List fetches one page of leads.
+func (s *LeadsService) List(ctx context.Context, p ListLeadsParams) (*Page[Lead], *Meta, error) {
+	return postPage[Lead](ctx, s.t, "/leads/list", p.body())
+}
+
+// All iterates over every matching lead, following cursors.
+func (s *LeadsService) All(ctx context.Context, p ListLeadsParams) iter.Seq2[Lead, error] {
+	return iterate(ctx, p.StartingAfter, func(ctx context.Context, cursor string) (*Page[Lead], error) {
+		p.StartingAfter = cursor
+		page, _, err := s.List(ctx, p)
+		return page, err
+	})
+}
+
+// Get fetches a lead by ID.
+func (s *LeadsService) Get(ctx context.Context, id string) (*Lead, *Meta, error) {
+	return getObject[Lead](ctx, s.t, pathID("/leads", id), nil)
+}
+
+// Create creates a lead.
+func (s *LeadsService) Create(ctx context.Context, req CreateLeadRequest) (*Lead, *Meta, error) {
+	return postObject[Lead](ctx, s.t, "/leads", req)
+}
+
+// Update patches a lead.
+func (s *LeadsService) Update(ctx context.Context, id string, req UpdateLeadRequest) (*Lead, *Meta, error) {
+	return patchObject[Lead](ctx, s.t, pathID("/leads", id), req)
+}
+
+// Delete deletes a lead and returns it as the API echoed it.
+func (s *LeadsService) Delete(ctx context.Context, id string) (*Lead, *Meta, error) {
+	return deleteObject[Lead](ctx, s.t, pathID("/leads", id))
+}
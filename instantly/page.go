@@ -0,0 +1,149 @@
+package instantly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// ListParams are the pagination and filter parameters shared by GET list endpoints.
+type ListParams struct {
+	// Limit is the page size. Zero leaves it to the API default.
+	Limit int
+	// StartingAfter is the cursor from a previous page's NextStartingAfter.
+	StartingAfter string
+	// Search is a free-text filter, for endpoints that support it.
+	Search string
+	// Query holds extra query parameters; they override the typed fields above.
+	Query url.Values
+}
+
+func (p ListParams) values() url.Values {
+	q := url.Values{}
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.StartingAfter != "" {
+		q.Set("starting_after", p.StartingAfter)
+	}
+	if p.Search != "" {
+		q.Set("search", p.Search)
+	}
+	return q
+}
+
+func mergeQuery(q, extra url.Values) url.Values {
+	for k, v := range extra {
+		q[k] = v
+	}
+	return q
+}
+
+// Page is one page of a cursor-paginated list.
+type Page[T any] struct {
+	Items []T
+	// NextStartingAfter is the cursor for the next page; empty on the last page.
+	NextStartingAfter string
+	// Raw is the decoded response body, including fields not modeled here.
+	// Numbers in Raw are json.Number.
+	Raw map[string]any
+}
+
+// raw is embedded in every resource so callers keep fields the typed model
+// does not cover (the CLI prints Raw to stay faithful to the API response).
+// Numbers in Raw are json.Number, so they re-encode exactly as received.
+type raw struct {
+	Raw map[string]any `json:"-"`
+}
+
+func (r *raw) setRaw(m map[string]any) { r.Raw = m }
+
+type rawSetter[T any] interface {
+	*T
+	setRaw(map[string]any)
+}
+
+func decodeObject[T any, PT rawSetter[T]](v any) (*T, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response shape: expected a JSON object")
+	}
+	out := new(T)
+	convert(m, out)
+	PT(out).setRaw(m)
+	return out, nil
+}
+
+func decodePage[T any, PT rawSetter[T]](v any) (*Page[T], error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response shape: expected a JSON object")
+	}
+	page := &Page[T]{Raw: m, NextStartingAfter: nextCursor(m)}
+	rawItems, _ := m["items"].([]any)
+	page.Items = make([]T, 0, len(rawItems))
+	for _, it := range rawItems {
+		// Items that are not objects stay zero values; Raw still has them.
+		item := new(T)
+		if m, ok := it.(map[string]any); ok {
+			convert(m, item)
+			PT(item).setRaw(m)
+		}
+		page.Items = append(page.Items, *item)
+	}
+	return page, nil
+}
+
+// nextCursor finds next_starting_after at the top level or in a nested pagination object.
+func nextCursor(m map[string]any) string {
+	if next, _ := m["next_starting_after"].(string); next != "" {
+		return next
+	}
+	if p, ok := m["pagination"].(map[string]any); ok {
+		if next, _ := p["next_starting_after"].(string); next != "" {
+			return next
+		}
+	}
+	return ""
+}
+
+// convert fills out's typed fields from a decoded object as best it can. A
+// field whose JSON type does not match (a string status, a fractional delay)
+// keeps its zero value; the API's value is still in Raw. encoding/json skips
+// such fields and decodes the rest, so its type errors are ignored, and in
+// (already decoded JSON) always re-encodes.
+func convert(in map[string]any, out any) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(b, out)
+}
+
+// iterate yields every item across pages, starting at cursor start.
+func iterate[T any](ctx context.Context, start string, fetch func(ctx context.Context, cursor string) (*Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		cursor := start
+		for {
+			page, err := fetch(ctx, cursor)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, it := range page.Items {
+				if !yield(it, nil) {
+					return
+				}
+			}
+			// Guard against endpoints that echo the same cursor forever.
+			if page.NextStartingAfter == "" || page.NextStartingAfter == cursor {
+				return
+			}
+			cursor = page.NextStartingAfter
+		}
+	}
+}
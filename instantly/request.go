@@ -0,0 +1,64 @@
+package instantly
+
+import (
+	"context"
+	"net/url"
+)
+
+func getObject[T any, PT rawSetter[T]](ctx context.Context, t *Transport, path string, q url.Values) (*T, *Meta, error) {
+	resp, meta, err := t.GetJSON(ctx, path, q)
+	if err != nil {
+		return nil, meta, err
+	}
+	out, err := decodeObject[T, PT](resp)
+	return out, meta, err
+}
+
+func postObject[T any, PT rawSetter[T]](ctx context.Context, t *Transport, path string, body any) (*T, *Meta, error) {
+	resp, meta, err := t.PostJSON(ctx, path, nil, body)
+	if err != nil {
+		return nil, meta, err
+	}
+	out, err := decodeObject[T, PT](resp)
+	return out, meta, err
+}
+
+func patchObject[T any, PT rawSetter[T]](ctx context.Context, t *Transport, path string, body any) (*T, *Meta, error) {
+	resp, meta, err := t.PatchJSON(ctx, path, nil, body)
+	if err != nil {
+		return nil, meta, err
+	}
+	out, err := decodeObject[T, PT](resp)
+	return out, meta, err
+}
+
+func deleteObject[T any, PT rawSetter[T]](ctx context.Context, t *Transport, path string) (*T, *Meta, error) {
+	resp, meta, err := t.DeleteJSON(ctx, path, nil)
+	if err != nil {
+		return nil, meta, err
+	}
+	out, err := decodeObject[T, PT](resp)
+	return out, meta, err
+}
+
+func getPage[T any, PT rawSetter[T]](ctx context.Context, t *Transport, path string, q url.Values) (*Page[T], *Meta, error) {
+	resp, meta, err := t.GetJSON(ctx, path, q)
+	if err != nil {
+		return nil, meta, err
+	}
+	page, err := decodePage[T, PT](resp)
+	return page, meta, err
+}
+
+func postPage[T any, PT rawSetter[T]](ctx context.Context, t *Transport, path string, body any) (*Page[T], *Meta, error) {
+	resp, meta, err := t.PostJSON(ctx, path, nil, body)
+	if err != nil {
+		return nil, meta, err
+	}
+	page, err := decodePage[T, PT](resp)
+	return page, meta, err
+}
+
+func pathID(prefix, id string) string {
+	return prefix + "/" + url.PathEscape(id)
+}
@@ -0,0 +1,45 @@
+package instantly
+
+import (
+	"context"
+	"iter"
+)
+
+// Tag is a custom tag that can be attached to accounts and campaigns.
+type Tag struct {
+	raw
+	ID               string `json:"id"`
+	Label            string `json:"label"`
+	Description      string `json:"description"`
+	Color            string `json:"color"`
+	TimestampCreated string `json:"timestamp_created"`
+}
+
+// ListTagsParams filters GET /custom-tags.
+type ListTagsParams struct {
+	ListParams
+}
+
+// TagsService covers /custom-tags.
+type TagsService struct {
+	t *Transport
+}
+
+// List fetches one page of custom tags.
+func (s *TagsService) List(ctx context.Context, p ListTagsParams) (*Page[Tag], *Meta, error) {
+	return getPage[Tag](ctx, s.t, "/custom-tags", mergeQuery(p.values(), p.Query))
+}
+
+// All iterates over every custom tag, following cursors.
+func (s *TagsService) All(ctx context.Context, p ListTagsParams) iter.Seq2[Tag, error] {
+	return iterate(ctx, p.StartingAfter, func(ctx context.Context, cursor string) (*Page[Tag], error) {
+		p.StartingAfter = cursor
+		page, _, err := s.List(ctx, p)
+		return page, err
+	})
+}
+
+// Get fetches a custom tag by ID.
+func (s *TagsService) Get(ctx context.Context, id string) (*Tag, *Meta, error) {
+	return getObject[Tag](ctx, s.t, pathID("/custom-tags", id), nil)
+}
@@ -0,0 +1,60 @@
+package instantly
+
+import (
+	"context"
+	"iter"
+)
+
+// Webhook is an event subscription that posts to TargetHookURL.
+type Webhook struct {
+	raw
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	TargetHookURL    string `json:"target_hook_url"`
+	EventType        string `json:"event_type"`
+	Campaign         string `json:"campaign"`
+	TimestampCreated string `json:"timestamp_created"`
+}
+
+// ListWebhooksParams filters GET /webhooks.
+type ListWebhooksParams struct {
+	ListParams
+	Campaign  string
+	EventType string
+}
+
+// WebhooksService covers /webhooks.
+type WebhooksService struct {
+	t *Transport
+}
+
+// List fetches one page of webhooks.
+func (s *WebhooksService) List(ctx context.Context, p ListWebhooksParams) (*Page[Webhook], *Meta, error) {
+	q := p.values()
+	if p.Campaign != "" {
+		q.Set("campaign", p.Campaign)
+	}
+	if p.EventType != "" {
+		q.Set("event_type", p.EventType)
+	}
+	return getPage[Webhook](ctx, s.t, "/webhooks", mergeQuery(q, p.Query))
+}
+
+// All iterates over every webhook, following cursors.
+func (s *WebhooksService) All(ctx context.Context, p ListWebhooksParams) iter.Seq2[Webhook, error] {
+	return iterate(ctx, p.StartingAfter, func(ctx context.Context, cursor string) (*Page[Webhook], error) {
+		p.StartingAfter = cursor
+		page, _, err := s.List(ctx, p)
+		return page, err
+	})
+}
+
+// Get fetches a webhook by ID.
+func (s *WebhooksService) Get(ctx context.Context, id string) (*Webhook, *Meta, error) {
+	return getObject[Webhook](ctx, s.t, pathID("/webhooks", id), nil)
+}
+
+// Delete deletes a webhook and returns it as the API echoed it.
+func (s *WebhooksService) Delete(ctx context.Context, id string) (*Webhook, *Meta, error) {
+	return deleteObject[Webhook](ctx, s.t, pathID("/webhooks", id))
+}
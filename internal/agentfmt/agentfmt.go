@@ -1,6 +1,7 @@
 package agentfmt
 
 import (
+	"maps"
 	"strings"
 )
 
@@ -50,3 +51,149 @@ func Envelope(kind string, resp any, meta map[string]any) any {
 	}
 	return DataEnvelope{Kind: kind, Data: resp, Meta: meta}
 }
+
+// SchemaVersion is the current envelope version. Version 1 is the
+// ListEnvelope/ItemEnvelope/DataEnvelope shape produced by Envelope.
+const SchemaVersion = 2
+
+// Type discriminates v2 envelopes and names the key that holds the payload.
+type Type string
+
+const (
+	TypeList   Type = "list"   // payload in "items"
+	TypeItem   Type = "item"   // payload in "item"
+	TypeData   Type = "data"   // payload in "data"
+	TypeAction Type = "action" // payload in "result"
+)
+
+// Timing reports the command's wall-clock time.
+type Timing struct {
+	StartedAt  string `json:"started_at"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Header is the part of a v2 envelope shared by every type.
+type Header struct {
+	SchemaVersion int    `json:"schema_version"`
+	Kind          string `json:"kind"`
+	Type          Type   `json:"type"`
+	// Warnings is always present (possibly empty) so agents can test its length.
+	Warnings []string       `json:"warnings"`
+	Timing   *Timing        `json:"timing,omitempty"`
+	Meta     map[string]any `json:"meta,omitempty"`
+}
+
+// ListEnvelopeV2 wraps list outputs.
+type ListEnvelopeV2 struct {
+	Header
+	Items any `json:"items"`
+}
+
+// ItemEnvelopeV2 wraps single-object outputs.
+type ItemEnvelopeV2 struct {
+	Header
+	Item any `json:"item"`
+}
+
+// DataEnvelopeV2 wraps scalar and other untyped outputs.
+type DataEnvelopeV2 struct {
+	Header
+	Data any `json:"data"`
+}
+
+// ActionEnvelopeV2 wraps the response of a command that changes state
+// (delete, pause, send...) rather than creating or reading a resource.
+type ActionEnvelopeV2 struct {
+	Header
+	Result any `json:"result"`
+}
+
+// Options carries the v2 fields that are not derived from the response.
+type Options struct {
+	// Action marks the response as the outcome of an action; lists are still lists.
+	Action   bool
+	Warnings []string
+	Timing   *Timing
+}
+
+// listKeys are the keys list endpoints wrap their array in, in lookup order.
+var listKeys = []string{"items", "data", "results", "records"}
+
+// ListItems reports whether resp is a list and returns its elements: a bare
+// array, or an object whose only array-valued wrapper key is one of listKeys.
+// Objects with an "id" are resources, not wrappers.
+func ListItems(resp any) (any, bool) {
+	items, _, ok := unwrapList(resp)
+	return items, ok
+}
+
+// unwrapList is ListItems that also returns the wrapper object's other keys
+// (total, count, next_starting_after...).
+func unwrapList(resp any) (items any, rest map[string]any, ok bool) {
+	switch v := resp.(type) {
+	case []any:
+		return v, nil, true
+	case map[string]any:
+		key := ""
+		if _, ok := v["items"]; ok {
+			// v1 treated any "items" key as a list; keep that.
+			key = "items"
+		} else if _, ok := v["id"]; ok {
+			return nil, nil, false
+		} else {
+			for _, k := range listKeys[1:] {
+				if _, ok := v[k].([]any); ok {
+					key = k
+					break
+				}
+			}
+		}
+		if key == "" {
+			return nil, nil, false
+		}
+		for k, val := range v {
+			if k != key {
+				if rest == nil {
+					rest = map[string]any{}
+				}
+				rest[k] = val
+			}
+		}
+		return v[key], rest, true
+	}
+	return nil, nil, false
+}
+
+// EnvelopeV2 wraps resp in the v2 envelope for kind.
+func EnvelopeV2(kind string, resp any, meta map[string]any, opts Options) any {
+	h := Header{
+		SchemaVersion: SchemaVersion,
+		Kind:          kind,
+		Warnings:      opts.Warnings,
+		Timing:        opts.Timing,
+		Meta:          meta,
+	}
+	if h.Warnings == nil {
+		h.Warnings = []string{}
+	}
+	if items, rest, ok := unwrapList(resp); ok {
+		h.Type = TypeList
+		if len(rest) > 0 {
+			// Keep the wrapper's other keys; the CLI's own meta wins on a clash.
+			h.Meta = make(map[string]any, len(rest)+len(meta))
+			maps.Copy(h.Meta, rest)
+			maps.Copy(h.Meta, meta)
+		}
+		return ListEnvelopeV2{Header: h, Items: items}
+	}
+	if opts.Action {
+		h.Type = TypeAction
+		return ActionEnvelopeV2{Header: h, Result: resp}
+	}
+	if _, ok := resp.(map[string]any); ok {
+		h.Type = TypeItem
+		return ItemEnvelopeV2{Header: h, Item: resp}
+	}
+	h.Type = TypeData
+	return DataEnvelopeV2{Header: h, Data: resp}
+}
@@ -32,3 +32,54 @@ func TestEnvelope_ListItemData(t *testing.T) {
 		t.Fatalf("data envelope type = %T", data)
 	}
 }
+
+func TestEnvelopeV2_Types(t *testing.T) {
+	cases := []struct {
+		name string
+		resp any
+		opts Options
+		want Type
+	}{
+		{"items", map[string]any{"items": []any{}}, Options{}, TypeList},
+		{"bare_array", []any{map[string]any{"id": 1}}, Options{}, TypeList},
+		{"data_wrapper", map[string]any{"data": []any{1}, "total": 1}, Options{}, TypeList},
+		{"resource_with_data", map[string]any{"id": "x", "data": []any{1}}, Options{}, TypeItem},
+		{"object", map[string]any{"id": 1}, Options{}, TypeItem},
+		{"scalar", "ok", Options{}, TypeData},
+		{"action", map[string]any{"id": 1}, Options{Action: true}, TypeAction},
+		{"action_list", []any{}, Options{Action: true}, TypeList},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := EnvelopeV2("x.y", tc.resp, nil, tc.opts)
+			var h Header
+			switch e := env.(type) {
+			case ListEnvelopeV2:
+				h = e.Header
+			case ItemEnvelopeV2:
+				h = e.Header
+			case DataEnvelopeV2:
+				h = e.Header
+			case ActionEnvelopeV2:
+				h = e.Header
+			default:
+				t.Fatalf("type %T", env)
+			}
+			if h.Type != tc.want || h.SchemaVersion != SchemaVersion || h.Warnings == nil {
+				t.Fatalf("header=%#v", h)
+			}
+		})
+	}
+}
+
+func TestEnvelopeV2_WrapperKeysInMeta(t *testing.T) {
+	resp := map[string]any{"data": []any{1}, "total": 7, "pagination": "api"}
+	meta := map[string]any{"pagination": "cli"}
+	env := EnvelopeV2("x.y", resp, meta, Options{}).(ListEnvelopeV2)
+	if env.Meta["total"] != 7 || env.Meta["pagination"] != "cli" {
+		t.Fatalf("meta=%#v", env.Meta)
+	}
+	if _, ok := meta["total"]; ok {
+		t.Fatalf("caller's meta was modified: %#v", meta)
+	}
+}
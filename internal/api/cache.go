@@ -0,0 +1,146 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache status values reported in Meta.Cache.
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheStale = "stale"
+)
+
+// Cache is an opt-in on-disk cache for successful GET responses.
+//
+// Entries are keyed by the full request URL and live under a per-API-key
+// directory, so workspaces never see each other's data. Any write (POST, PATCH,
+// DELETE) through the same client drops every entry under the written
+// resource's top-level path, e.g. PATCH /campaigns/1 invalidates GET /campaigns
+// and GET /campaigns/1. POSTs that only read (see readOnlyPost) invalidate
+// nothing. Streamed list pages are not stored: buffering them would defeat
+// streaming, so they always report a miss or stale.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+type cacheEntry struct {
+	URL      string    `json:"url"`
+	Path     string    `json:"path"`
+	StoredAt time.Time `json:"stored_at"`
+	Body     string    `json:"body"`
+}
+
+// NewCache returns a cache rooted at dir for apiKey's responses.
+func NewCache(dir, apiKey string, ttl time.Duration) *Cache {
+	return &Cache{Dir: filepath.Join(dir, KeyFingerprint(apiKey)), TTL: ttl}
+}
+
+// DefaultCacheDir returns the response cache root in the user cache dir.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "instantly", "http"), nil
+}
+
+// get returns the cached body for fullURL and its status: hit, stale (expired,
+// body nil) or miss.
+func (c *Cache) get(fullURL string) ([]byte, string) {
+	b, err := os.ReadFile(c.file(fullURL))
+	if err != nil {
+		return nil, CacheMiss
+	}
+	var e cacheEntry
+	if json.Unmarshal(b, &e) != nil || e.URL != fullURL {
+		return nil, CacheMiss
+	}
+	if timeNow().Sub(e.StoredAt) >= c.TTL {
+		return nil, CacheStale
+	}
+	return []byte(e.Body), CacheHit
+}
+
+// put stores body for fullURL. Failures are ignored; the cache is best effort.
+func (c *Cache) put(fullURL string, body []byte) {
+	e := cacheEntry{URL: fullURL, Path: urlPath(fullURL), StoredAt: timeNow(), Body: string(body)}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(c.Dir, 0o700) != nil {
+		return
+	}
+	path := c.file(fullURL)
+	tmp := path + ".tmp"
+	if os.WriteFile(tmp, b, 0o600) != nil {
+		return
+	}
+	_ = os.Rename(tmp, path)
+}
+
+// invalidate removes entries under the top-level resource of the written URL.
+func (c *Cache) invalidate(fullURL string) {
+	prefix := resourcePrefix(urlPath(fullURL))
+	files, err := filepath.Glob(filepath.Join(c.Dir, "*.json"))
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		var e cacheEntry
+		if json.Unmarshal(b, &e) != nil || e.Path == prefix || strings.HasPrefix(e.Path, prefix+"/") {
+			_ = os.Remove(f)
+		}
+	}
+}
+
+func (c *Cache) file(fullURL string) string {
+	sum := sha256.Sum256([]byte(fullURL))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+func urlPath(fullURL string) string {
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return fullURL
+	}
+	return u.Path
+}
+
+// readOnlyPost reports whether a POST to path only reads: list, search, count
+// and analytics endpoints that take their filters in the body, e.g.
+// /leads/list or /accounts/warmup-analytics.
+func readOnlyPost(path string) bool {
+	last := path[strings.LastIndex(path, "/")+1:]
+	switch {
+	case last == "list", strings.HasPrefix(last, "search"), strings.HasPrefix(last, "count"),
+		strings.HasPrefix(last, "preview"), strings.HasSuffix(last, "analytics"):
+		return true
+	}
+	return false
+}
+
+// resourcePrefix maps /api/v2/campaigns/1/activate to /api/v2/campaigns by
+// dropping everything after the first segment that follows the base path.
+func resourcePrefix(path string) string {
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	// Skip a leading "api/v2"-style base path.
+	i := 0
+	if len(segs) > 2 && segs[0] == "api" && strings.HasPrefix(segs[1], "v") {
+		i = 2
+	}
+	return "/" + strings.Join(segs[:i+1], "/")
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache_HitStaleAndInvalidate(t *testing.T) {
+	var gets int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			atomic.AddInt32(&gets, 1)
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	now := time.Unix(1_700_000_000, 0)
+	old := timeNow
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = old })
+
+	dir := t.TempDir()
+	c := NewClient(srv.URL, "k", 5*time.Second)
+	c.Cache = NewCache(dir, "k", time.Minute)
+	ctx := context.Background()
+
+	get := func(path, want string) {
+		t.Helper()
+		_, meta, err := c.GetJSON(ctx, path, nil)
+		if err != nil {
+			t.Fatalf("get %s: %v", path, err)
+		}
+		if meta.Cache != want {
+			t.Fatalf("get %s cache=%q want %q", path, meta.Cache, want)
+		}
+	}
+
+	get("/campaigns", CacheMiss)
+	get("/campaigns", CacheHit)
+	get("/campaigns/1", CacheMiss)
+	get("/accounts", CacheMiss)
+	if n := atomic.LoadInt32(&gets); n != 3 {
+		t.Fatalf("gets=%d", n)
+	}
+
+	// A different API key has its own cache.
+	other := NewClient(srv.URL, "k2", 5*time.Second)
+	other.Cache = NewCache(dir, "k2", time.Minute)
+	if _, meta, _ := other.GetJSON(ctx, "/campaigns", nil); meta.Cache != CacheMiss {
+		t.Fatalf("other key cache=%q", meta.Cache)
+	}
+
+	// A read-only POST (filters in the body) leaves the cache alone.
+	if _, _, err := c.PostJSON(ctx, "/campaigns/list", nil, map[string]any{"limit": 1}); err != nil {
+		t.Fatalf("post list: %v", err)
+	}
+	get("/campaigns", CacheHit)
+
+	// A write under /campaigns drops both campaign entries but not /accounts.
+	if _, _, err := c.PostJSON(ctx, "/campaigns/1/pause", nil, nil); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	get("/campaigns", CacheMiss)
+	get("/campaigns/1", CacheMiss)
+	get("/accounts", CacheHit)
+
+	now = now.Add(2 * time.Minute)
+	get("/accounts", CacheStale)
+	get("/accounts", CacheHit)
+}
+
+func TestReadOnlyPost(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/api/v2/leads/list":                                   true,
+		"/accounts/warmup-analytics":                           true,
+		"/supersearch-enrichment/count-leads-from-supersearch": true,
+		"/campaigns/search-by-contact":                         true,
+		"/leads":                                               false,
+		"/leads/merge":                                         false,
+		"/campaigns/1/pause":                                   false,
+	} {
+		if got := readOnlyPost(path); got != want {
+			t.Errorf("readOnlyPost(%q)=%v want %v", path, got, want)
+		}
+	}
+}
+
+func TestResourcePrefix(t *testing.T) {
+	cases := map[string]string{
+		"/api/v2/campaigns/1/activate": "/api/v2/campaigns",
+		"/campaigns/1":                 "/campaigns",
+		"/leads":                       "/leads",
+	}
+	for in, want := range cases {
+		if got := resourcePrefix(in); got != want {
+			t.Fatalf("resourcePrefix(%q)=%q want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const redactedSecret = "REDACTED"
+
+// minRedactLen guards against a tiny test key rewriting unrelated bytes.
+const minRedactLen = 8
+
+// Interaction is one recorded request/response pair, stored as a JSON file in a cassette dir.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that saves every exchange to Dir, one file per
+// request, with the API key and credential-like header values redacted.
+type Recorder struct {
+	Dir  string
+	Next http.RoundTripper
+	// Redact names further request headers (e.g. from --header) whose values
+	// are redacted, in addition to the ones sensitiveHeader recognizes.
+	Redact []string
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewRecorder records through next (http.DefaultTransport when nil) into dir.
+func NewRecorder(dir string, next http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create cassette dir: %w", err)
+	}
+	files, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	// Append to an existing cassette instead of overwriting it.
+	return &Recorder{Dir: dir, Next: next, seq: len(files)}, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+
+	resp, err := r.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	secret := bearerToken(req.Header.Get("Authorization"))
+	it := Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: req.Header.Clone(),
+			Body:    string(reqBody),
+		},
+		Response: RecordedResponse{
+			Status:  resp.StatusCode,
+			Headers: resp.Header.Clone(),
+			Body:    string(respBody),
+		},
+	}
+	secrets := []string{secret}
+	for name, vs := range it.Request.Headers {
+		if r.redacts(name) {
+			secrets = append(secrets, vs...)
+			redactHeader(it.Request.Headers, name)
+		}
+	}
+	for name := range it.Response.Headers {
+		if sensitiveHeader(name) {
+			redactHeader(it.Response.Headers, name)
+		}
+	}
+	if err := r.save(it, secrets...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *Recorder) save(it Interaction, secrets ...string) error {
+	b, err := json.MarshalIndent(it, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode interaction: %w", err)
+	}
+	// Also scrub the values wherever they are echoed, e.g. in a response body.
+	for _, secret := range secrets {
+		b = redactSecret(b, secret)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	name := fmt.Sprintf("%04d-%s-%s.json", r.seq, strings.ToLower(it.Request.Method), cassetteSlug(it.Request.URL))
+	if err := os.WriteFile(filepath.Join(r.Dir, name), append(b, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+func (r *Recorder) redacts(name string) bool {
+	if sensitiveHeader(name) {
+		return true
+	}
+	for _, n := range r.Redact {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveHeader reports whether a header usually carries a credential:
+// Authorization, cookies, and X-API-Key/X-Auth-Token style headers.
+func sensitiveHeader(name string) bool {
+	n := strings.ToLower(name)
+	for _, s := range []string{"auth", "cookie", "token", "secret", "session", "api-key", "apikey", "password"} {
+		if strings.Contains(n, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactHeader replaces every value of name, keeping the scheme of an
+// Authorization-style "Bearer <token>" value.
+func redactHeader(h http.Header, name string) {
+	for i, v := range h[name] {
+		if scheme, _, ok := strings.Cut(v, " "); ok && strings.Contains(strings.ToLower(name), "auth") {
+			h[name][i] = scheme + " " + redactedSecret
+			continue
+		}
+		h[name][i] = redactedSecret
+	}
+}
+
+// Replayer is an http.RoundTripper that answers from a cassette dir and never
+// touches the network. Requests match on method, path+query and body (the host is
+// ignored so a cassette replays against any --base-url); repeated identical requests
+// are served in recorded order.
+type Replayer struct {
+	Dir string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer loads every interaction in dir.
+func NewReplayer(dir string) (*Replayer, error) {
+	files, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	r := &Replayer{Dir: dir}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("read cassette: %w", err)
+		}
+		var it Interaction
+		if err := json.Unmarshal(b, &it); err != nil {
+			return nil, fmt.Errorf("decode cassette %s: %w", filepath.Base(f), err)
+		}
+		r.interactions = append(r.interactions, it)
+	}
+	r.used = make([]bool, len(r.interactions))
+	return r, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+	// Recorded bodies had the key redacted; compare like with like.
+	body = redactSecret(body, bearerToken(req.Header.Get("Authorization")))
+	uri := req.URL.RequestURI()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, it := range r.interactions {
+		if r.used[i] || it.Request.Method != req.Method || string(body) != it.Request.Body {
+			continue
+		}
+		if recordedRequestURI(it.Request.URL) != uri {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", it.Response.Status, http.StatusText(it.Response.Status)),
+			StatusCode:    it.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        it.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(it.Response.Body)),
+			ContentLength: int64(len(it.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("replay: no recorded response for %s %s in %s", req.Method, req.URL.RequestURI(), r.Dir)
+}
+
+func cassetteFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func bearerToken(auth string) string {
+	_, token, ok := strings.Cut(auth, " ")
+	if !ok {
+		token = auth
+	}
+	return strings.TrimSpace(token)
+}
+
+func recordedRequestURI(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return u.RequestURI()
+}
+
+func redactSecret(b []byte, secret string) []byte {
+	if len(secret) < minRedactLen {
+		return b
+	}
+	return bytes.ReplaceAll(b, []byte(secret), []byte(redactedSecret))
+}
+
+var slugUnsafe = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+func cassetteSlug(rawURL string) string {
+	path := recordedRequestURI(rawURL)
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	s := strings.Trim(slugUnsafe.ReplaceAllString(path, "-"), "-")
+	if len(s) > 60 {
+		s = s[:60]
+	}
+	if s == "" {
+		s = "root"
+	}
+	return s
+}
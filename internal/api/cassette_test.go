@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRecordReplay_RoundTrip(t *testing.T) {
+	const key = "sk-live-abcdef123456"
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			// Echo the key back to make sure response bodies are redacted too.
+			_, _ = w.Write([]byte(`{"id":"k1","key":"` + key + `"}`))
+			return
+		}
+		if n == 1 {
+			_, _ = w.Write([]byte(`{"status":"pending"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"done"}`))
+	}))
+	dir := filepath.Join(t.TempDir(), "cassette")
+
+	c := NewClient(srv.URL, key, 5*time.Second)
+	rec, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("recorder: %v", err)
+	}
+	c.HTTPClient.Transport = rec
+	ctx := context.Background()
+	for _, path := range []string{"/jobs/1", "/jobs/1"} {
+		if _, _, err := c.GetJSON(ctx, path, nil); err != nil {
+			t.Fatalf("get: %v", err)
+		}
+	}
+	if _, _, err := c.PostJSON(ctx, "/api-keys", nil, map[string]any{"name": "n"}); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	srv.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 || !strings.HasSuffix(files[0], "0001-get-jobs-1.json") {
+		t.Fatalf("files=%v", files)
+	}
+	for _, f := range files {
+		b, _ := os.ReadFile(f)
+		if strings.Contains(string(b), key) {
+			t.Fatalf("%s leaks the api key:\n%s", f, b)
+		}
+	}
+	b, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(b), "Bearer REDACTED") {
+		t.Fatalf("authorization not redacted:\n%s", b)
+	}
+
+	// Replay against a different host with the server gone: identical requests come back in order.
+	rep, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatalf("replayer: %v", err)
+	}
+	c = NewClient("http://replay.invalid", key, 5*time.Second)
+	c.HTTPClient.Transport = rep
+	for _, want := range []string{"pending", "done"} {
+		out, _, err := c.GetJSON(ctx, "/jobs/1", nil)
+		if err != nil {
+			t.Fatalf("replay get: %v", err)
+		}
+		if got := out.(map[string]any)["status"]; got != want {
+			t.Fatalf("status=%v want %v", got, want)
+		}
+	}
+	if _, _, err := c.PostJSON(ctx, "/api-keys", nil, map[string]any{"name": "n"}); err != nil {
+		t.Fatalf("replay post: %v", err)
+	}
+	if _, _, err := c.GetJSON(ctx, "/jobs/1", nil); err == nil || !strings.Contains(err.Error(), "no recorded response for GET /jobs/1") {
+		t.Fatalf("expected exhausted cassette error, got %v", err)
+	}
+	if _, _, err := c.PostJSON(ctx, "/api-keys", nil, map[string]any{"name": "other"}); err == nil {
+		t.Fatalf("expected body mismatch error")
+	}
+}
+
+func TestRecorder_AppendsToExistingCassette(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "0001-get-x.json"), []byte(`{}`), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	rec, err := NewRecorder(dir, rtFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 204, Header: http.Header{}, Body: http.NoBody, Request: r}, nil
+	}))
+	if err != nil {
+		t.Fatalf("recorder: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodDelete, "http://x/webhooks/w1", nil)
+	if _, err := rec.RoundTrip(req); err != nil {
+		t.Fatalf("roundtrip: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "0002-delete-webhooks-w1.json")); err != nil {
+		t.Fatalf("expected second file: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "0003-bad.json"), []byte(`{`), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := NewReplayer(dir); err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
+
+func TestRecorder_RedactsCredentialHeaders(t *testing.T) {
+	const gateway = "gw-token-0123456789"
+	const cookie = "session=cookie-secret-abcdef"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=server-secret-abcdef")
+		w.Header().Set("Content-Type", "application/json")
+		// Echo the gateway token to make sure bodies are scrubbed too.
+		_, _ = w.Write([]byte(`{"seen":"` + r.Header.Get("X-Gateway") + `"}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	rec, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatalf("recorder: %v", err)
+	}
+	rec.Redact = []string{"x-gateway"}
+	c := NewClient(srv.URL, "sk-live-abcdef123456", 5*time.Second)
+	// Headers are added outside the Recorder, as --header does.
+	c.HTTPClient.Transport = rec
+	c.Use(WithHeaders(http.Header{"X-Gateway": {gateway}, "Cookie": {cookie}, "X-Api-Key": {"other-key-98765432"}}))
+	if _, _, err := c.GetJSON(context.Background(), "/campaigns", nil); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("files=%v", files)
+	}
+	b, _ := os.ReadFile(files[0])
+	for _, secret := range []string{gateway, "cookie-secret", "server-secret", "other-key-98765432"} {
+		if strings.Contains(string(b), secret) {
+			t.Fatalf("cassette leaks %q:\n%s", secret, b)
+		}
+	}
+	if !strings.Contains(string(b), `"X-Gateway": [`+"\n"+`        "REDACTED"`) {
+		t.Fatalf("X-Gateway not redacted:\n%s", b)
+	}
+}
@@ -13,12 +13,14 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/salmonumbrella/instantly-cli/internal/jsonnum"
 )
 
 var randIntn = rand.Intn
 var randInt63n = rand.Int63n
 var jsonMarshal = json.Marshal
-var jsonUnmarshal = json.Unmarshal
+var jsonUnmarshal = jsonnum.Unmarshal
 
 const (
 	DefaultBaseURL = "https://api.instantly.ai/api/v2"
@@ -37,6 +39,23 @@ type Client struct {
 	RetryDelay     time.Duration
 	MaxRetryDelay  time.Duration
 	IdempotencyKey string
+
+	// RateLimiter paces requests before the quota runs out. Nil (the NewClient
+	// default) disables pacing; the CLI installs one unless --no-rate-limit.
+	RateLimiter *RateLimiter
+
+	// Trace, when set, is called once per HTTP attempt (see NewDebugTracer).
+	Trace func(TraceEvent)
+
+	// Cache, when set, serves repeated GETs from disk and is invalidated by writes.
+	Cache *Cache
+
+	// Middleware wraps HTTPClient's transport for every request (see Use).
+	Middleware []Middleware
+
+	// Journal, when set and IdempotencyKey is empty, assigns each write a persisted
+	// idempotency key so identical re-runs reuse it (and writes become retryable).
+	Journal *Journal
 }
 
 // NewClient creates a new API client.
@@ -70,12 +89,45 @@ type Meta struct {
 		URL    string `json:"url"`
 	} `json:"request"`
 	RateLimit *RateLimitInfo `json:"rate_limit,omitempty"`
+	// Cache is hit, miss or stale when a response cache is configured.
+	Cache string `json:"cache,omitempty"`
+	// Idempotency is set when the key came from the write journal.
+	Idempotency *IdempotencyInfo `json:"idempotency,omitempty"`
+
+	// Attempts lists every HTTP attempt made, in order (empty for dry-run and cache hits).
+	Attempts []Attempt `json:"attempts,omitempty"`
+	// TotalBackoffMS is the time slept between attempts.
+	TotalBackoffMS int64 `json:"total_backoff_ms,omitempty"`
+	// RetryAfterMS is the last Retry-After delay honored (after the MaxRetryDelay cap).
+	RetryAfterMS int64 `json:"retry_after_ms,omitempty"`
+}
+
+// Retry reasons recorded on Attempt.RetryReason.
+const (
+	RetryRateLimited = "rate_limited"
+	RetryServerError = "server_error"
+	RetryNetwork     = "network_error"
+)
+
+// Attempt describes one HTTP attempt made by Client.do.
+type Attempt struct {
+	// Status is the HTTP status (0 when the request failed before a response).
+	Status     int    `json:"status,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+	// RetryReason is set when this attempt was retried.
+	RetryReason string `json:"retry_reason,omitempty"`
+	BackoffMS   int64  `json:"backoff_ms,omitempty"`
 }
 
 type APIError struct {
 	Status  int
 	Message string
 	Body    []byte
+	// RetryAfter is the server's Retry-After hint, when it sent one.
+	RetryAfter time.Duration
+	// Details lists field-level validation failures from a 4xx body, when present.
+	Details []FieldError
 }
 
 func (e *APIError) Error() string {
@@ -88,9 +140,21 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("instantly api error (http %d)", e.Status)
 }
 
+// ErrMissingAPIKey is returned when a request needs an API key and none is set.
+var ErrMissingAPIKey = errors.New("missing API key: set INSTANTLY_API_KEY or pass --api-key")
+
+// DecodeError reports a response body that is not the JSON the client expected.
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string { return "decode json: " + e.Err.Error() }
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
 func (c *Client) ensureAPIKey() error {
 	if strings.TrimSpace(c.APIKey) == "" {
-		return errors.New("missing API key: set INSTANTLY_API_KEY or pass --api-key")
+		return ErrMissingAPIKey
 	}
 	return nil
 }
@@ -225,12 +289,58 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 		return nil, nil, err
 	}
 
-	canRetryWrite := method == http.MethodGet || strings.TrimSpace(c.IdempotencyKey) != ""
+	stream := itemStreamFrom(ctx)
+
+	cacheStatus := ""
+	if c.Cache != nil {
+		if method == http.MethodGet {
+			cached, status := c.Cache.get(fullURL)
+			if status == CacheHit {
+				meta := &Meta{Cache: CacheHit}
+				meta.Request.Method = method
+				meta.Request.URL = fullURL
+				if stream != nil {
+					rest, err := streamItems(bytes.NewReader(cached), stream)
+					return rest, meta, err
+				}
+				return cached, meta, nil
+			}
+			cacheStatus = status
+		} else if method != http.MethodPost || !readOnlyPost(urlPath(fullURL)) {
+			// Invalidate even if the write fails: the server may have applied it anyway.
+			defer c.Cache.invalidate(fullURL)
+		}
+	}
 
+	idempotencyKey := strings.TrimSpace(c.IdempotencyKey)
+	var (
+		idem       *IdempotencyInfo
+		lastStatus int
+	)
+	if idempotencyKey == "" && c.Journal != nil && method != http.MethodGet {
+		id, info, err := c.Journal.key(ctx, method, fullURL, body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("idempotency journal: %w", err)
+		}
+		idempotencyKey, idem = info.Key, info
+		defer func() { c.Journal.finish(id, lastStatus) }()
+	}
+
+	canRetryWrite := method == http.MethodGet || idempotencyKey != ""
+
+	// One Meta accumulates every attempt so callers see retries even on failure.
+	meta := &Meta{Cache: cacheStatus, Idempotency: idem}
+	meta.Request.Method = method
+	meta.Request.URL = fullURL
+
+	httpClient := c.httpClient()
 	retries429 := 0
 	retries5xx := 0
-	var lastMeta *Meta
-	for {
+	for attempt := 1; ; attempt++ {
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return nil, meta.orNil(), err
+		}
+
 		var bodyReader io.Reader
 		if body != nil {
 			bodyReader = bytes.NewReader(body)
@@ -238,7 +348,7 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 
 		req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 		if err != nil {
-			return nil, lastMeta, fmt.Errorf("create request: %w", err)
+			return nil, meta.orNil(), fmt.Errorf("create request: %w", err)
 		}
 
 		// Some Instantly endpoints reject empty JSON bodies when a JSON content-type is present.
@@ -247,42 +357,92 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 			req.Header.Set("Content-Type", "application/json")
 		}
 		req.Header.Set("Authorization", "Bearer "+c.APIKey)
-		if strings.TrimSpace(c.IdempotencyKey) != "" {
-			req.Header.Set("Idempotency-Key", c.IdempotencyKey)
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+
+		var ev *TraceEvent
+		if c.Trace != nil {
+			ev = &TraceEvent{
+				Time:        timeNow(),
+				Attempt:     attempt,
+				Method:      method,
+				URL:         fullURL,
+				Headers:     redactHeaders(req.Header),
+				RequestBody: traceBody(body),
+			}
 		}
+		meta.Attempts = append(meta.Attempts, Attempt{})
+		att := &meta.Attempts[len(meta.Attempts)-1]
+		start := time.Now()
 
-		resp, err := c.HTTPClient.Do(req)
+		resp, err := httpClient.Do(req)
 		if err != nil {
+			att.DurationMS = time.Since(start).Milliseconds()
+			att.Error = err.Error()
+			if ev != nil {
+				ev.Latency = time.Since(start)
+				ev.Error = err.Error()
+			}
 			// Network errors are treated like 5xx retries for GETs / idempotent writes.
 			if (method == http.MethodGet || canRetryWrite) && retries5xx < c.Max5xxRetries && c.Max5xxRetries > 0 {
 				retries5xx++
-				if sleepErr := sleepWithBackoff(ctx, c.RetryDelay, c.MaxRetryDelay, retries5xx); sleepErr != nil {
-					return nil, lastMeta, sleepErr
+				if sleepErr := c.retrySleep(ctx, meta, ev, RetryNetwork, withJitter(backoffDelay(c.RetryDelay, c.MaxRetryDelay, retries5xx))); sleepErr != nil {
+					return nil, meta, sleepErr
 				}
 				continue
 			}
-			return nil, lastMeta, fmt.Errorf("request failed: %w", err)
+			c.trace(ev)
+			return nil, meta, fmt.Errorf("request failed: %w", err)
 		}
 
-		respBody, readErr := io.ReadAll(resp.Body)
+		// Rate-limit headers are needed before a long streamed read, not after.
+		meta.RateLimit = parseRateLimit(resp.Header)
+		c.RateLimiter.Observe(meta.RateLimit)
+
+		counted := &countingReader{r: resp.Body}
+		streamed := stream != nil && resp.StatusCode < 400
+		var (
+			respBody []byte
+			readErr  error
+		)
+		if streamed {
+			// The page is handed to stream item by item; respBody is what remains.
+			respBody, readErr = streamItems(counted, stream)
+		} else {
+			respBody, readErr = io.ReadAll(counted)
+		}
 		_ = resp.Body.Close()
+		lastStatus = resp.StatusCode
+		att.Status = resp.StatusCode
+		att.DurationMS = time.Since(start).Milliseconds()
+		if ev != nil {
+			ev.Latency = time.Since(start)
+			ev.Status = resp.StatusCode
+			ev.ResponseBytes = counted.n
+			if resp.StatusCode >= 400 {
+				ev.ResponseBody = traceBody(respBody)
+			}
+		}
 		if readErr != nil {
-			return nil, lastMeta, fmt.Errorf("read response: %w", readErr)
+			att.Error = readErr.Error()
+			if ev != nil {
+				ev.Error = readErr.Error()
+			}
+			c.trace(ev)
+			return nil, meta, fmt.Errorf("read response: %w", readErr)
 		}
 
-		meta := &Meta{}
-		meta.Request.Method = method
-		meta.Request.URL = fullURL
-		meta.RateLimit = parseRateLimit(resp.Header)
-		lastMeta = meta
-
 		if resp.StatusCode == http.StatusTooManyRequests && (method == http.MethodGet || canRetryWrite) && c.Max429Retries > 0 && retries429 < c.Max429Retries {
 			retries429++
 			delay := retryAfterDelay(resp.Header, c.RetryDelay)
 			if delay > c.MaxRetryDelay && c.MaxRetryDelay > 0 {
 				delay = c.MaxRetryDelay
 			}
-			if sleepErr := sleepWithJitter(ctx, delay); sleepErr != nil {
+			if resp.Header.Get("Retry-After") != "" {
+				meta.RetryAfterMS = delay.Milliseconds()
+			}
+			if sleepErr := c.retrySleep(ctx, meta, ev, RetryRateLimited, withJitter(delay)); sleepErr != nil {
 				return nil, meta, sleepErr
 			}
 			continue
@@ -290,24 +450,63 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 
 		if resp.StatusCode >= 500 && resp.StatusCode <= 599 && (method == http.MethodGet || canRetryWrite) && c.Max5xxRetries > 0 && retries5xx < c.Max5xxRetries {
 			retries5xx++
-			if sleepErr := sleepWithBackoff(ctx, c.RetryDelay, c.MaxRetryDelay, retries5xx); sleepErr != nil {
+			if sleepErr := c.retrySleep(ctx, meta, ev, RetryServerError, withJitter(backoffDelay(c.RetryDelay, c.MaxRetryDelay, retries5xx))); sleepErr != nil {
 				return nil, meta, sleepErr
 			}
 			continue
 		}
 
+		c.trace(ev)
 		if resp.StatusCode >= 400 {
-			return nil, meta, &APIError{
-				Status:  resp.StatusCode,
-				Message: parseAPIErrorMessage(resp.StatusCode, respBody),
-				Body:    respBody,
+			apiErr := &APIError{
+				Status:     resp.StatusCode,
+				Message:    parseAPIErrorMessage(resp.StatusCode, respBody),
+				Body:       respBody,
+				RetryAfter: retryAfterDelay(resp.Header, 0),
+			}
+			if resp.StatusCode < 500 {
+				apiErr.Details = parseAPIErrorDetails(respBody)
 			}
+			return nil, meta, apiErr
 		}
 
+		if cacheStatus != "" && !streamed {
+			c.Cache.put(fullURL, respBody)
+		}
 		return respBody, meta, nil
 	}
 }
 
+// orNil keeps the historical nil Meta when no attempt was made yet.
+func (m *Meta) orNil() *Meta {
+	if len(m.Attempts) == 0 {
+		return nil
+	}
+	return m
+}
+
+func (c *Client) trace(ev *TraceEvent) {
+	if ev != nil && c.Trace != nil {
+		c.Trace(*ev)
+	}
+}
+
+// retrySleep records the retry decision on the current attempt (and trace event),
+// emits the trace, then waits out delay.
+func (c *Client) retrySleep(ctx context.Context, meta *Meta, ev *TraceEvent, reason string, delay time.Duration) error {
+	delay = max(delay, 0)
+	att := &meta.Attempts[len(meta.Attempts)-1]
+	att.RetryReason = reason
+	att.BackoffMS = delay.Milliseconds()
+	meta.TotalBackoffMS += delay.Milliseconds()
+	if ev != nil {
+		ev.RetryReason = reason
+		ev.Backoff = delay
+	}
+	c.trace(ev)
+	return sleepCtx(ctx, delay)
+}
+
 func retryAfterDelay(headers http.Header, fallback time.Duration) time.Duration {
 	v := strings.TrimSpace(headers.Get("Retry-After"))
 	if v == "" {
@@ -320,12 +519,12 @@ func retryAfterDelay(headers http.Header, fallback time.Duration) time.Duration
 	return fallback
 }
 
-func sleepWithBackoff(ctx context.Context, base, max time.Duration, attempt int) error {
+// backoffDelay is base * 2^(attempt-1), capped at max.
+func backoffDelay(base, max time.Duration, attempt int) time.Duration {
 	if base <= 0 {
 		base = 1 * time.Second
 	}
 	delay := base
-	// Exponential: base * 2^(attempt-1)
 	for i := 1; i < attempt; i++ {
 		delay *= 2
 		if max > 0 && delay >= max {
@@ -333,21 +532,25 @@ func sleepWithBackoff(ctx context.Context, base, max time.Duration, attempt int)
 			break
 		}
 	}
-	return sleepWithJitter(ctx, delay)
+	return delay
 }
 
-func sleepWithJitter(ctx context.Context, d time.Duration) error {
+// withJitter applies +/- 20% jitter to d.
+func withJitter(d time.Duration) time.Duration {
 	if d <= 0 {
-		return nil
+		return d
 	}
-	// +/- 20% jitter.
 	j := time.Duration(randInt63n(int64(d/5) + 1)) // d/5 = 20%
 	if randIntn(2) == 0 {
-		d -= j
-	} else {
-		d += j
+		return d - j
 	}
+	return d + j
+}
 
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
 	t := time.NewTimer(d)
 	defer t.Stop()
 	select {
@@ -367,8 +570,8 @@ func (c *Client) GetJSON(ctx context.Context, path string, query url.Values) (an
 		return map[string]any{"success": true}, meta, nil
 	}
 	var v any
-	if err := json.Unmarshal(body, &v); err != nil {
-		return nil, meta, fmt.Errorf("decode json: %w", err)
+	if err := jsonnum.Unmarshal(body, &v); err != nil {
+		return nil, meta, &DecodeError{Err: err}
 	}
 	return v, meta, nil
 }
@@ -390,8 +593,8 @@ func (c *Client) PostJSON(ctx context.Context, path string, query url.Values, pa
 		return map[string]any{"success": true}, meta, nil
 	}
 	var v any
-	if err := json.Unmarshal(respBody, &v); err != nil {
-		return nil, meta, fmt.Errorf("decode json: %w", err)
+	if err := jsonnum.Unmarshal(respBody, &v); err != nil {
+		return nil, meta, &DecodeError{Err: err}
 	}
 	return v, meta, nil
 }
@@ -413,8 +616,8 @@ func (c *Client) PatchJSON(ctx context.Context, path string, query url.Values, p
 		return map[string]any{"success": true}, meta, nil
 	}
 	var v any
-	if err := json.Unmarshal(respBody, &v); err != nil {
-		return nil, meta, fmt.Errorf("decode json: %w", err)
+	if err := jsonnum.Unmarshal(respBody, &v); err != nil {
+		return nil, meta, &DecodeError{Err: err}
 	}
 	return v, meta, nil
 }
@@ -428,8 +631,8 @@ func (c *Client) DeleteJSON(ctx context.Context, path string, query url.Values)
 		return map[string]any{"success": true}, meta, nil
 	}
 	var v any
-	if err := json.Unmarshal(respBody, &v); err != nil {
-		return nil, meta, fmt.Errorf("decode json: %w", err)
+	if err := jsonnum.Unmarshal(respBody, &v); err != nil {
+		return nil, meta, &DecodeError{Err: err}
 	}
 	return v, meta, nil
 }
@@ -453,8 +656,8 @@ func (c *Client) DeleteJSONWithBody(ctx context.Context, path string, query url.
 		return map[string]any{"success": true}, meta, nil
 	}
 	var v any
-	if err := json.Unmarshal(respBody, &v); err != nil {
-		return nil, meta, fmt.Errorf("decode json: %w", err)
+	if err := jsonnum.Unmarshal(respBody, &v); err != nil {
+		return nil, meta, &DecodeError{Err: err}
 	}
 	return v, meta, nil
 }
@@ -258,11 +258,12 @@ func TestRetryAfterDelay(t *testing.T) {
 	}
 }
 
-func TestSleepHelpers(t *testing.T) {
-	ctx, cancel := context.WithCancel(context.Background())
-	cancel()
-	if err := sleepWithJitter(ctx, 0); err != nil {
-		t.Fatalf("err=%v", err)
+func TestBackoffAndJitter(t *testing.T) {
+	if d := backoffDelay(0, 0, 1); d != time.Second {
+		t.Fatalf("default base: d=%v", d)
+	}
+	if d := backoffDelay(time.Millisecond, 3*time.Millisecond, 3); d != 3*time.Millisecond {
+		t.Fatalf("capped: d=%v", d)
 	}
 
 	oldIntn := randIntn
@@ -273,20 +274,22 @@ func TestSleepHelpers(t *testing.T) {
 	})
 
 	// Cover both +/- jitter branches deterministically.
-	randInt63n = func(_ int64) int64 { return 0 }
+	randInt63n = func(_ int64) int64 { return 10 }
 	randIntn = func(_ int) int { return 0 }
-	if err := sleepWithJitter(ctx, 1*time.Millisecond); !errors.Is(err, context.Canceled) {
-		t.Fatalf("err=%v", err)
+	if d := withJitter(100); d != 90 {
+		t.Fatalf("d=%v", d)
 	}
 	randIntn = func(_ int) int { return 1 }
-	if err := sleepWithJitter(ctx, 1*time.Millisecond); !errors.Is(err, context.Canceled) {
-		t.Fatalf("err=%v", err)
+	if d := withJitter(100); d != 110 {
+		t.Fatalf("d=%v", d)
 	}
 
-	if err := sleepWithBackoff(ctx, 1*time.Millisecond, 2*time.Millisecond, 2); !errors.Is(err, context.Canceled) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleepCtx(ctx, 0); err != nil {
 		t.Fatalf("err=%v", err)
 	}
-	if err := sleepWithBackoff(ctx, 0, 0, 1); !errors.Is(err, context.Canceled) {
+	if err := sleepCtx(ctx, time.Millisecond); !errors.Is(err, context.Canceled) {
 		t.Fatalf("err=%v", err)
 	}
 }
@@ -665,3 +668,80 @@ func TestClient_PatchAndDelete_DoError(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestGetJSON_PreservesNumbers(t *testing.T) {
+	const body = `{"count":1000000,"id":12345678901234567890,"rate":0.1,"ts_ms":1700000000123}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	out, _, err := NewClient(srv.URL, "k", time.Second).GetJSON(context.Background(), "/x", nil)
+	if err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	b, _ := json.Marshal(out)
+	if string(b) != body {
+		t.Fatalf("got %s want %s", b, body)
+	}
+}
+
+func TestDo_MetaRecordsAttempts(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	defer srv.Close()
+
+	oldIntn := randIntn
+	oldInt63n := randInt63n
+	t.Cleanup(func() {
+		randIntn = oldIntn
+		randInt63n = oldInt63n
+	})
+	randIntn = func(_ int) int { return 0 }
+	randInt63n = func(_ int64) int64 { return 0 }
+
+	c := NewClient(srv.URL, "k", time.Second)
+	c.Max429Retries = 1
+	c.Max5xxRetries = 1
+	c.RetryDelay = 3 * time.Millisecond
+	c.MaxRetryDelay = 5 * time.Millisecond
+
+	_, meta, err := c.GetJSON(context.Background(), "/x", nil)
+	if err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if len(meta.Attempts) != 3 {
+		t.Fatalf("attempts=%#v", meta.Attempts)
+	}
+	a := meta.Attempts
+	if a[0].Status != 429 || a[0].RetryReason != RetryRateLimited || a[0].BackoffMS != 5 ||
+		a[1].Status != 503 || a[1].RetryReason != RetryServerError || a[1].BackoffMS != 3 ||
+		a[2].Status != 200 || a[2].RetryReason != "" {
+		t.Fatalf("attempts=%#v", a)
+	}
+	// Retry-After: 7 is honored up to MaxRetryDelay.
+	if meta.TotalBackoffMS != 8 || meta.RetryAfterMS != 5 {
+		t.Fatalf("backoff=%d retry_after=%d", meta.TotalBackoffMS, meta.RetryAfterMS)
+	}
+}
+
+func TestDo_NetworkErrorKeepsMeta(t *testing.T) {
+	c := NewClient("http://example.com", "k", time.Second)
+	c.HTTPClient.Transport = rtFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("dial boom")
+	})
+	_, meta, err := c.GetJSON(context.Background(), "/x", nil)
+	if err == nil || meta == nil || len(meta.Attempts) != 1 || !strings.Contains(meta.Attempts[0].Error, "dial boom") {
+		t.Fatalf("err=%v meta=%#v", err, meta)
+	}
+}
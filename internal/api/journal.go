@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultJournalTTL is how long a recorded write keeps its idempotency key.
+const DefaultJournalTTL = 24 * time.Hour
+
+// Journal persists automatically generated idempotency keys so re-running an
+// identical write (same command, method, URL and payload) that never got a 2xx
+// reuses its key instead of creating a duplicate. Once a write has succeeded, an
+// identical re-run is treated as a new write and gets a fresh key. The journal is
+// shared between processes via a lock file.
+type Journal struct {
+	Path string
+	TTL  time.Duration
+	// Scope namespaces entries, typically the CLI command path.
+	Scope string
+}
+
+// JournalEntry is one logical write recorded in the journal.
+type JournalEntry struct {
+	Key         string    `json:"key"`
+	Command     string    `json:"command,omitempty"`
+	Method      string    `json:"method"`
+	URL         string    `json:"url"`
+	PayloadHash string    `json:"payload_sha256"`
+	CreatedAt   time.Time `json:"created_at"`
+	// LastStatus is the HTTP status of the most recent attempt (0 = no response).
+	LastStatus int `json:"last_status,omitempty"`
+}
+
+// IdempotencyInfo reports the key sent with a write.
+type IdempotencyInfo struct {
+	Key    string `json:"key"`
+	Reused bool   `json:"reused"`
+	// PreviousStatus is the last HTTP status seen for a reused key (0 = no response).
+	PreviousStatus int `json:"previous_status,omitempty"`
+}
+
+// NewJournal returns a journal stored at path with the default TTL.
+func NewJournal(path, scope string) *Journal {
+	return &Journal{Path: path, TTL: DefaultJournalTTL, Scope: scope}
+}
+
+// DefaultJournalPath returns a per-API-key journal file in the user cache dir.
+func DefaultJournalPath(apiKey string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "instantly", "idempotency-"+KeyFingerprint(apiKey)+".json"), nil
+}
+
+// key returns the idempotency key for a write, creating and persisting one when
+// the write has not been seen within TTL or its last attempt succeeded.
+func (j *Journal) key(ctx context.Context, method, fullURL string, body []byte) (id string, info *IdempotencyInfo, err error) {
+	id = j.entryID(method, fullURL, body)
+	unlock, err := lockFile(ctx, j.Path+".lock")
+	if err != nil {
+		return "", nil, err
+	}
+	defer unlock()
+
+	entries := j.load()
+	now := timeNow()
+	for k, e := range entries {
+		if now.Sub(e.CreatedAt) >= j.TTL {
+			delete(entries, k)
+		}
+	}
+	if e, ok := entries[id]; ok && (e.LastStatus < 200 || e.LastStatus > 299) {
+		return id, &IdempotencyInfo{Key: e.Key, Reused: true, PreviousStatus: e.LastStatus}, nil
+	}
+
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return "", nil, err
+	}
+	sum := sha256.Sum256(body)
+	entries[id] = JournalEntry{
+		Key:         key,
+		Command:     j.Scope,
+		Method:      method,
+		URL:         fullURL,
+		PayloadHash: hex.EncodeToString(sum[:]),
+		CreatedAt:   now,
+	}
+	if err := j.save(entries); err != nil {
+		return "", nil, err
+	}
+	return id, &IdempotencyInfo{Key: key}, nil
+}
+
+// finish records the final HTTP status for an entry. Best effort.
+func (j *Journal) finish(id string, status int) {
+	unlock, _ := lockFile(context.Background(), j.Path+".lock")
+	defer unlock()
+	entries := j.load()
+	e, ok := entries[id]
+	if !ok {
+		return
+	}
+	e.LastStatus = status
+	entries[id] = e
+	_ = j.save(entries)
+}
+
+func (j *Journal) entryID(method, fullURL string, body []byte) string {
+	h := sha256.New()
+	for _, part := range [][]byte{[]byte(j.Scope), []byte(method), []byte(fullURL), body} {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (j *Journal) load() map[string]JournalEntry {
+	entries := map[string]JournalEntry{}
+	b, err := os.ReadFile(j.Path)
+	if err == nil {
+		_ = json.Unmarshal(b, &entries)
+	}
+	return entries
+}
+
+func (j *Journal) save(entries map[string]JournalEntry) error {
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.Path), 0o700); err != nil {
+		return fmt.Errorf("create journal dir: %w", err)
+	}
+	tmp := j.Path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return fmt.Errorf("write journal: %w", err)
+	}
+	return os.Rename(tmp, j.Path)
+}
+
+// newIdempotencyKey returns a random UUIDv4.
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generate idempotency key: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestJournal_ReuseExpireAndFinish(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	old := timeNow
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = old })
+
+	path := filepath.Join(t.TempDir(), "j", "journal.json")
+	j := NewJournal(path, "instantly leads create")
+
+	id, first, err := j.key(context.Background(), "POST", "https://x/leads", []byte(`{"email":"a"}`))
+	if err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if first.Reused || !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(first.Key) {
+		t.Fatalf("first=%#v", first)
+	}
+	j.finish(id, 502)
+	if e := j.load()[id]; e.LastStatus != 502 || e.Command != "instantly leads create" || e.PayloadHash == "" {
+		t.Fatalf("entry=%#v", e)
+	}
+
+	// Another process (a fresh Journal on the same file) sees the same key.
+	_, again, _ := NewJournal(path, "instantly leads create").key(context.Background(), "POST", "https://x/leads", []byte(`{"email":"a"}`))
+	if !again.Reused || again.Key != first.Key || again.PreviousStatus != 502 {
+		t.Fatalf("again=%#v", again)
+	}
+
+	// Once the write succeeded, an identical re-run is a new write.
+	j.finish(id, 201)
+	_, fresh, _ := j.key(context.Background(), "POST", "https://x/leads", []byte(`{"email":"a"}`))
+	if fresh.Reused || fresh.Key == first.Key {
+		t.Fatalf("fresh=%#v", fresh)
+	}
+
+	// Same request from a different command is a different logical write.
+	_, scoped, _ := NewJournal(path, "instantly api post").key(context.Background(), "POST", "https://x/leads", []byte(`{"email":"a"}`))
+	if scoped.Reused {
+		t.Fatalf("scoped=%#v", scoped)
+	}
+
+	now = now.Add(DefaultJournalTTL)
+	_, expired, _ := j.key(context.Background(), "POST", "https://x/leads", []byte(`{"email":"a"}`))
+	if expired.Reused || expired.Key == fresh.Key {
+		t.Fatalf("expired=%#v", expired)
+	}
+}
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var timeNow = time.Now
+
+const (
+	lockPollInterval = 5 * time.Millisecond
+	lockWaitTimeout  = 2 * time.Second
+	// A lock file older than this is assumed to belong to a crashed process.
+	lockStaleAfter = 10 * time.Second
+	// resetSpread spaces requests queued behind an exhausted budget when the
+	// window length or limit is unknown.
+	resetSpread = 50 * time.Millisecond
+)
+
+// RateLimiter paces requests from the x-ratelimit-* response headers so callers
+// slow down before the quota runs out instead of after a 429.
+//
+// It is safe for concurrent use. With StatePath set, parallel processes using the
+// same file share one budget; the file is guarded by a sibling ".lock" file.
+type RateLimiter struct {
+	// StatePath optionally persists limiter state for other processes.
+	StatePath string
+	// Reserve is how many requests per window are held back (never spent by pacing).
+	Reserve int
+	// LowWater is the fraction of the limit below which requests are spread
+	// evenly over the rest of the window.
+	LowWater float64
+
+	mu    sync.Mutex
+	state rateState
+}
+
+type rateState struct {
+	Remaining int       `json:"remaining"`
+	Limit     int       `json:"limit,omitempty"`
+	ResetAt   time.Time `json:"reset_at"`
+	// Window is the longest time-to-reset seen, an estimate of the window length.
+	Window time.Duration `json:"window,omitempty"`
+	// Next is the earliest time the next request may start.
+	Next time.Time `json:"next"`
+}
+
+// NewRateLimiter returns a limiter with default thresholds. statePath may be empty.
+func NewRateLimiter(statePath string) *RateLimiter {
+	return &RateLimiter{StatePath: statePath, Reserve: 1, LowWater: 0.2}
+}
+
+// DefaultRateLimitStatePath returns a per-API-key state file in the user cache dir.
+func DefaultRateLimitStatePath(apiKey string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "instantly", "ratelimit-"+KeyFingerprint(apiKey)+".json"), nil
+}
+
+// KeyFingerprint is a short, non-reversible identifier for an API key.
+func KeyFingerprint(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:6])
+}
+
+// Wait blocks until the next request may be sent, or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	delay, err := l.reserve(ctx)
+	if err != nil || delay <= 0 {
+		return err
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// reserve claims the next send slot and returns how long to wait for it.
+func (l *RateLimiter) reserve(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	unlock, err := l.load(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	now := timeNow()
+	s := &l.state
+	if s.ResetAt.IsZero() || !now.Before(s.ResetAt) {
+		// No known window (or it already reset): nothing to pace against.
+		return 0, nil
+	}
+
+	slot := now
+	if s.Next.After(slot) {
+		slot = s.Next
+	}
+	var interval time.Duration
+	switch {
+	case s.Remaining <= l.Reserve:
+		// Budget exhausted: hold until the window resets, then space the queued
+		// callers across the new window instead of releasing them all at once.
+		if s.ResetAt.After(slot) {
+			slot = s.ResetAt
+		}
+		interval = resetSpread
+		if s.Window > 0 && s.Limit > 0 {
+			interval = s.Window / time.Duration(s.Limit)
+		}
+	case s.Limit > 0 && float64(s.Remaining) < float64(s.Limit)*l.LowWater:
+		// Running low: spread what is left evenly over the rest of the window.
+		interval = s.ResetAt.Sub(slot) / time.Duration(s.Remaining-l.Reserve)
+	}
+	if slot.Before(s.ResetAt) {
+		s.Remaining--
+	}
+	s.Next = slot.Add(interval)
+	l.save()
+	return slot.Sub(now), nil
+}
+
+// Observe records the rate limit headers from a response.
+func (l *RateLimiter) Observe(info *RateLimitInfo) {
+	if l == nil || info == nil || info.Remaining == nil || info.ResetAt == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Observe runs after a response and holds the lock briefly; lockFile's own
+	// timeout bounds it.
+	unlock, _ := l.load(context.Background())
+	defer unlock()
+
+	s := &l.state
+	if w := info.ResetAt.Sub(timeNow()); w > s.Window {
+		s.Window = w
+	}
+	remaining := *info.Remaining
+	// Responses from concurrent requests can arrive out of order; within one
+	// window, the lowest remaining count (including local reservations) wins.
+	if s.ResetAt.Equal(*info.ResetAt) && s.Remaining < remaining {
+		remaining = s.Remaining
+	}
+	s.Remaining = remaining
+	s.ResetAt = *info.ResetAt
+	if info.Limit != nil {
+		s.Limit = *info.Limit
+	}
+	l.save()
+}
+
+// load refreshes state from StatePath under the file lock and returns the unlock func.
+func (l *RateLimiter) load(ctx context.Context) (func(), error) {
+	if l.StatePath == "" {
+		return func() {}, nil
+	}
+	unlock, err := lockFile(ctx, l.StatePath+".lock")
+	if err != nil {
+		return unlock, err
+	}
+	b, err := os.ReadFile(l.StatePath)
+	if err == nil {
+		var s rateState
+		if json.Unmarshal(b, &s) == nil {
+			l.state = s
+		}
+	}
+	return unlock, nil
+}
+
+// save writes state to StatePath. Failures are ignored: pacing is best effort and
+// must never fail a request.
+func (l *RateLimiter) save() {
+	if l.StatePath == "" {
+		return
+	}
+	b, err := json.Marshal(l.state)
+	if err != nil {
+		return
+	}
+	tmp := l.StatePath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, l.StatePath)
+}
+
+// lockFile takes a cross-process lock by exclusively creating path. It gives up
+// after lockWaitTimeout and proceeds unlocked rather than blocking requests,
+// and returns ctx's error if ctx is done first.
+func lockFile(ctx context.Context, path string) (func(), error) {
+	noop := func() {}
+	deadline := timeNow().Add(lockWaitTimeout)
+	madeDir := false
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		switch {
+		case errors.Is(err, os.ErrNotExist) && !madeDir:
+			madeDir = true
+			if os.MkdirAll(filepath.Dir(path), 0o700) != nil {
+				return noop, nil
+			}
+			continue
+		case errors.Is(err, os.ErrExist):
+			if fi, statErr := os.Stat(path); statErr == nil && timeNow().Sub(fi.ModTime()) > lockStaleAfter {
+				_ = os.Remove(path)
+				continue
+			}
+		default:
+			return noop, nil
+		}
+		if timeNow().After(deadline) {
+			return noop, nil
+		}
+		t := time.NewTimer(lockPollInterval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return noop, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fixedNow(t *testing.T, now time.Time) {
+	t.Helper()
+	old := timeNow
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = old })
+}
+
+func intPtr(n int) *int { return &n }
+
+func mustReserve(t *testing.T, l *RateLimiter) time.Duration {
+	t.Helper()
+	d, err := l.reserve(context.Background())
+	if err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	return d
+}
+
+func TestRateLimiter_NoWindowNoWait(t *testing.T) {
+	l := NewRateLimiter("")
+	if d := mustReserve(t, l); d != 0 {
+		t.Fatalf("delay=%v", d)
+	}
+	var nilLimiter *RateLimiter
+	if err := nilLimiter.Wait(context.Background()); err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	nilLimiter.Observe(&RateLimitInfo{})
+}
+
+func TestRateLimiter_PacesBelowLowWater(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	fixedNow(t, now)
+	reset := now.Add(10 * time.Second)
+
+	l := NewRateLimiter("")
+	l.Observe(&RateLimitInfo{Remaining: intPtr(11), Limit: intPtr(100), ResetAt: &reset})
+
+	// 11 remaining, 1 reserved: 10 requests spread over 10s.
+	if d := mustReserve(t, l); d != 0 {
+		t.Fatalf("first delay=%v", d)
+	}
+	if d := mustReserve(t, l); d != time.Second {
+		t.Fatalf("second delay=%v", d)
+	}
+}
+
+func TestRateLimiter_PlentyRemainingNoPacing(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	fixedNow(t, now)
+	reset := now.Add(10 * time.Second)
+
+	l := NewRateLimiter("")
+	l.Observe(&RateLimitInfo{Remaining: intPtr(90), Limit: intPtr(100), ResetAt: &reset})
+	for i := 0; i < 5; i++ {
+		if d := mustReserve(t, l); d != 0 {
+			t.Fatalf("delay=%v", d)
+		}
+	}
+}
+
+func TestRateLimiter_ExhaustedWaitsForReset(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	fixedNow(t, now)
+	reset := now.Add(30 * time.Second)
+
+	l := NewRateLimiter("")
+	l.Observe(&RateLimitInfo{Remaining: intPtr(1), Limit: intPtr(100), ResetAt: &reset})
+	if d := mustReserve(t, l); d != 30*time.Second {
+		t.Fatalf("delay=%v", d)
+	}
+	// Later callers are spaced across the new window (30s / 100), not released together.
+	if d := mustReserve(t, l); d != 30*time.Second+300*time.Millisecond {
+		t.Fatalf("second delay=%v", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestRateLimiter_ObserveKeepsLowestInWindow(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	fixedNow(t, now)
+	reset := now.Add(time.Minute)
+
+	l := NewRateLimiter("")
+	l.Observe(&RateLimitInfo{Remaining: intPtr(5), ResetAt: &reset})
+	l.Observe(&RateLimitInfo{Remaining: intPtr(8), ResetAt: &reset})
+	if l.state.Remaining != 5 {
+		t.Fatalf("remaining=%d", l.state.Remaining)
+	}
+
+	next := reset.Add(time.Minute)
+	l.Observe(&RateLimitInfo{Remaining: intPtr(8), ResetAt: &next})
+	if l.state.Remaining != 8 {
+		t.Fatalf("new window remaining=%d", l.state.Remaining)
+	}
+}
+
+func TestRateLimiter_SharedStateFile(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	fixedNow(t, now)
+	reset := now.Add(time.Minute)
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	a := NewRateLimiter(path)
+	b := NewRateLimiter(path)
+	a.Observe(&RateLimitInfo{Remaining: intPtr(1), ResetAt: &reset})
+
+	// b never saw a response but must pick up a's exhausted budget.
+	if d := mustReserve(t, b); d != time.Minute {
+		t.Fatalf("delay=%v", d)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Fatalf("lock file left behind: %v", err)
+	}
+}
+
+func TestLockFile_BreaksStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.lock")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	unlock, _ := lockFile(context.Background(), path)
+	unlock()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected lock removed, err=%v", err)
+	}
+}
+
+func TestLockFile_HonorsContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.lock")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := lockFile(ctx, path); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err=%v", err)
+	}
+	if time.Since(start) >= lockWaitTimeout {
+		t.Fatalf("waited %v for a cancelled context", time.Since(start))
+	}
+}
+
+func TestNewClient_NoPacingByDefault(t *testing.T) {
+	if c := NewClient("", "k", 0); c.RateLimiter != nil {
+		t.Fatalf("NewClient should not pace unless a RateLimiter is set")
+	}
+}
+
+func TestClient_PacesFromHeaders(t *testing.T) {
+	var calls int32
+	reset := time.Now().Add(time.Hour).Unix()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("x-ratelimit-remaining", "0")
+		w.Header().Set("x-ratelimit-limit", "100")
+		w.Header().Set("x-ratelimit-reset", strconv.FormatInt(reset, 10))
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "k", 5*time.Second)
+	c.RateLimiter = NewRateLimiter("")
+	if _, _, err := c.GetJSON(context.Background(), "/x", nil); err != nil {
+		t.Fatalf("first call err=%v", err)
+	}
+
+	// The quota is spent: the next request must wait for the reset instead of going out.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := c.GetJSON(ctx, "/x", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err=%v", err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("calls=%d", calls)
+	}
+}
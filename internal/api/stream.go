@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ItemFunc receives one element of a list response's "items" array.
+type ItemFunc func(item any) error
+
+type itemStreamKey struct{}
+
+// WithItemStream returns a context under which successful responses are decoded
+// incrementally: each element of a top-level "items" array is passed to fn as soon
+// as it is read, and the body the caller gets back keeps every other field but has
+// "items": []. Memory use is then bounded by one item rather than one page.
+//
+// Like net/http/httptrace, the hook travels in the context so typed SDK calls
+// stream without new signatures. Streamed responses are not written to the cache.
+func WithItemStream(ctx context.Context, fn ItemFunc) context.Context {
+	return context.WithValue(ctx, itemStreamKey{}, fn)
+}
+
+func itemStreamFrom(ctx context.Context) ItemFunc {
+	fn, _ := ctx.Value(itemStreamKey{}).(ItemFunc)
+	return fn
+}
+
+// streamItems decodes r, handing "items" elements to fn, and returns the rest of
+// the response re-encoded as JSON. Bodies that are not objects are returned as-is.
+func streamItems(r io.Reader, fn ItemFunc) ([]byte, error) {
+	br := bufio.NewReader(r)
+	if !startsWithObject(br) {
+		return io.ReadAll(br)
+	}
+
+	dec := json.NewDecoder(br)
+	dec.UseNumber()
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	rest := map[string]any{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		if key != "items" {
+			var v any
+			if err := dec.Decode(&v); err != nil {
+				return nil, err
+			}
+			rest[key] = v
+			continue
+		}
+		if rest[key], err = decodeItems(dec, fn); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return jsonMarshal(rest)
+}
+
+// decodeItems streams an "items" array to fn and returns the empty placeholder.
+// Anything other than an array is decoded and returned unchanged.
+func decodeItems(dec *json.Decoder, fn ItemFunc) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('['):
+		for dec.More() {
+			var item any
+			if err := dec.Decode(&item); err != nil {
+				return nil, err
+			}
+			if err := fn(item); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return []any{}, nil
+	case json.Delim('{'):
+		obj := map[string]any{}
+		for dec.More() {
+			k, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			var v any
+			if err := dec.Decode(&v); err != nil {
+				return nil, err
+			}
+			key, _ := k.(string)
+			obj[key] = v
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	default:
+		return tok, nil
+	}
+}
+
+// startsWithObject skips leading whitespace and reports whether the next byte is '{'.
+func startsWithObject(br *bufio.Reader) bool {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return false
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		_ = br.UnreadByte()
+		return b == '{'
+	}
+}
+
+// countingReader counts bytes read, for trace output of streamed bodies.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStreamItems(t *testing.T) {
+	var got []any
+	rest, err := streamItems(strings.NewReader(` {"next_starting_after":"c1","items":[{"id":1},{"id":2}],"total":9007199254740993}`), func(it any) error {
+		got = append(got, it)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(got) != 2 || got[1].(map[string]any)["id"] != json.Number("2") {
+		t.Fatalf("items=%#v", got)
+	}
+	if string(rest) != `{"items":[],"next_starting_after":"c1","total":9007199254740993}` {
+		t.Fatalf("rest=%s", rest)
+	}
+
+	// Non-object bodies and non-array items pass through untouched.
+	rest, err = streamItems(strings.NewReader(`[1,2]`), func(any) error { t.Fatal("unexpected item"); return nil })
+	if err != nil || string(rest) != `[1,2]` {
+		t.Fatalf("rest=%s err=%v", rest, err)
+	}
+	rest, err = streamItems(strings.NewReader(`{"items":{"a":1}}`), func(any) error { t.Fatal("unexpected item"); return nil })
+	if err != nil || string(rest) != `{"items":{"a":1}}` {
+		t.Fatalf("rest=%s err=%v", rest, err)
+	}
+
+	if _, err := streamItems(strings.NewReader(`{"items":[]} {}`), func(any) error { return nil }); err == nil {
+		t.Fatalf("expected trailing data error")
+	}
+	stop := errors.New("stop")
+	if _, err := streamItems(strings.NewReader(`{"items":[1]}`), func(any) error { return stop }); !errors.Is(err, stop) {
+		t.Fatalf("err=%v", err)
+	}
+}
+
+func TestDo_WithItemStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":[{"id":"a"},{"id":"b"}],"next_starting_after":"b"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "k", 0)
+	var ids []string
+	ctx := WithItemStream(context.Background(), func(it any) error {
+		ids = append(ids, it.(map[string]any)["id"].(string))
+		return nil
+	})
+	v, meta, err := c.GetJSON(ctx, "/x", nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if strings.Join(ids, ",") != "a,b" {
+		t.Fatalf("ids=%v", ids)
+	}
+	m := v.(map[string]any)
+	if len(m["items"].([]any)) != 0 || m["next_starting_after"] != "b" {
+		t.Fatalf("rest=%#v", m)
+	}
+	if meta == nil || len(meta.Attempts) != 1 || meta.Attempts[0].Status != 200 {
+		t.Fatalf("meta=%#v", meta)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// traceBodyLimit caps how much of a request/response body is included in a trace.
+const traceBodyLimit = 4096
+
+// TraceEvent describes one HTTP attempt made by Client.do.
+type TraceEvent struct {
+	Time          time.Time         `json:"time"`
+	Attempt       int               `json:"attempt"`
+	Method        string            `json:"method"`
+	URL           string            `json:"url"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	RequestBody   string            `json:"request_body,omitempty"`
+	Status        int               `json:"status,omitempty"`
+	ResponseBytes int               `json:"response_bytes"`
+	ResponseBody  string            `json:"response_body,omitempty"`
+	Latency       time.Duration     `json:"-"`
+	LatencyMS     int64             `json:"latency_ms"`
+	Error         string            `json:"error,omitempty"`
+	RetryReason   string            `json:"retry_reason,omitempty"`
+	Backoff       time.Duration     `json:"-"`
+	BackoffMS     int64             `json:"backoff_ms,omitempty"`
+}
+
+// NewDebugTracer returns a Client.Trace func that writes one entry per attempt to w.
+// format is "json" (one object per line) or anything else for human-readable text.
+func NewDebugTracer(w io.Writer, format string) func(TraceEvent) {
+	var mu sync.Mutex
+	return func(ev TraceEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		if format == "json" {
+			ev.LatencyMS = ev.Latency.Milliseconds()
+			ev.BackoffMS = ev.Backoff.Milliseconds()
+			b, err := json.Marshal(ev)
+			if err != nil {
+				return
+			}
+			_, _ = fmt.Fprintf(w, "%s\n", b)
+			return
+		}
+		writeTraceText(w, ev)
+	}
+}
+
+func writeTraceText(w io.Writer, ev TraceEvent) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[debug] %s %s (attempt %d)\n", ev.Method, ev.URL, ev.Attempt)
+	keys := make([]string, 0, len(ev.Headers))
+	for k := range ev.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "[debug] > %s: %s\n", k, ev.Headers[k])
+	}
+	if ev.RequestBody != "" {
+		fmt.Fprintf(&b, "[debug] > %s\n", ev.RequestBody)
+	}
+	if ev.Error != "" {
+		fmt.Fprintf(&b, "[debug] < error: %s (%s)\n", ev.Error, ev.Latency.Round(time.Millisecond))
+	} else {
+		fmt.Fprintf(&b, "[debug] < %d %s, %d bytes in %s\n", ev.Status, http.StatusText(ev.Status), ev.ResponseBytes, ev.Latency.Round(time.Millisecond))
+	}
+	if ev.ResponseBody != "" {
+		fmt.Fprintf(&b, "[debug] < %s\n", ev.ResponseBody)
+	}
+	if ev.RetryReason != "" {
+		fmt.Fprintf(&b, "[debug] retry: %s, backing off %s\n", ev.RetryReason, ev.Backoff.Round(time.Millisecond))
+	}
+	_, _ = io.WriteString(w, b.String())
+}
+
+// redactHeaders flattens h for tracing with credentials masked.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, vs := range h {
+		v := strings.Join(vs, ", ")
+		if strings.EqualFold(k, "Authorization") {
+			v = redactAuthorization(v)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// redactAuthorization keeps the scheme and the last 4 characters of a token.
+func redactAuthorization(v string) string {
+	scheme, token, ok := strings.Cut(v, " ")
+	if !ok {
+		scheme, token = "", v
+	} else {
+		scheme += " "
+	}
+	if len(token) <= 8 {
+		return scheme + "****"
+	}
+	return scheme + "****" + token[len(token)-4:]
+}
+
+func traceBody(b []byte) string {
+	if len(b) > traceBodyLimit {
+		return string(b[:traceBodyLimit]) + "...(truncated)"
+	}
+	return string(b)
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTrace_RetryThenSuccess(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"message":"upstream"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	oldIntn := randIntn
+	oldInt63n := randInt63n
+	t.Cleanup(func() {
+		randIntn = oldIntn
+		randInt63n = oldInt63n
+	})
+	randIntn = func(_ int) int { return 0 }
+	randInt63n = func(_ int64) int64 { return 0 }
+
+	var events []TraceEvent
+	c := NewClient(srv.URL, "secret-api-key-1234", 5*time.Second)
+	c.IdempotencyKey = "idem"
+	c.Max5xxRetries = 1
+	c.RetryDelay = 2 * time.Millisecond
+	c.Trace = func(ev TraceEvent) { events = append(events, ev) }
+
+	if _, _, err := c.PostJSON(context.Background(), "/leads", nil, map[string]any{"email": "a@x"}); err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("events=%#v", events)
+	}
+	first, second := events[0], events[1]
+	if first.Attempt != 1 || first.Status != 502 || first.RetryReason != RetryServerError || first.Backoff != 2*time.Millisecond {
+		t.Fatalf("first=%#v", first)
+	}
+	if first.Headers["Authorization"] != "Bearer ****1234" || first.RequestBody != `{"email":"a@x"}` {
+		t.Fatalf("headers=%v body=%q", first.Headers, first.RequestBody)
+	}
+	if first.ResponseBody != `{"message":"upstream"}` {
+		t.Fatalf("response body=%q", first.ResponseBody)
+	}
+	if second.Attempt != 2 || second.Status != 200 || second.RetryReason != "" || second.ResponseBytes != len(`{"ok":true}`) || second.ResponseBody != "" {
+		t.Fatalf("second=%#v", second)
+	}
+}
+
+func TestDebugTracer_Formats(t *testing.T) {
+	ev := TraceEvent{
+		Attempt:       1,
+		Method:        http.MethodGet,
+		URL:           "https://x/y",
+		Headers:       map[string]string{"Authorization": "Bearer ****abcd"},
+		Status:        429,
+		ResponseBytes: 3,
+		Latency:       15 * time.Millisecond,
+		RetryReason:   "rate_limited",
+		Backoff:       time.Second,
+	}
+
+	var text bytes.Buffer
+	NewDebugTracer(&text, "text")(ev)
+	for _, want := range []string{"GET https://x/y (attempt 1)", "> Authorization: Bearer ****abcd", "< 429 Too Many Requests, 3 bytes in 15ms", "retry: rate_limited, backing off 1s"} {
+		if !strings.Contains(text.String(), want) {
+			t.Fatalf("missing %q in:\n%s", want, text.String())
+		}
+	}
+
+	var js bytes.Buffer
+	NewDebugTracer(&js, "json")(ev)
+	var m map[string]any
+	if err := json.Unmarshal(js.Bytes(), &m); err != nil {
+		t.Fatalf("json: %v (%q)", err, js.String())
+	}
+	if m["latency_ms"] != float64(15) || m["backoff_ms"] != float64(1000) || m["retry_reason"] != "rate_limited" {
+		t.Fatalf("m=%v", m)
+	}
+}
+
+func TestRedactAuthorization(t *testing.T) {
+	cases := map[string]string{
+		"Bearer abcdefghijkl": "Bearer ****ijkl",
+		"Bearer short":        "Bearer ****",
+		"rawtokenvalue":       "****alue",
+	}
+	for in, want := range cases {
+		if got := redactAuthorization(in); got != want {
+			t.Fatalf("redact(%q)=%q want %q", in, got, want)
+		}
+	}
+}
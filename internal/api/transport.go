@@ -0,0 +1,126 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Middleware wraps the HTTP transport, e.g. to add headers, log or audit requests.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// Use appends middleware. The first one added is the outermost: it sees each
+// request first and each response last.
+func (c *Client) Use(mw ...Middleware) {
+	c.Middleware = append(c.Middleware, mw...)
+}
+
+// httpClient returns HTTPClient with the middleware chain applied to its transport.
+func (c *Client) httpClient() *http.Client {
+	if len(c.Middleware) == 0 {
+		return c.HTTPClient
+	}
+	rt := c.HTTPClient.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		rt = c.Middleware[i](rt)
+	}
+	hc := *c.HTTPClient
+	hc.Transport = rt
+	return &hc
+}
+
+// WithHeaders returns middleware that sets h on every request.
+func WithHeaders(h http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			// RoundTrippers must not mutate the caller's request.
+			r = r.Clone(r.Context())
+			for k, vs := range h {
+				r.Header.Del(k)
+				for _, v := range vs {
+					r.Header.Add(k, v)
+				}
+			}
+			return next.RoundTrip(r)
+		})
+	}
+}
+
+// ParseHeaders parses "Key=Value" pairs (as given to --header) into an http.Header.
+func ParseHeaders(pairs []string) (http.Header, error) {
+	h := http.Header{}
+	for _, pair := range pairs {
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid header %q (expected key=value)", pair)
+		}
+		h.Add(k, strings.TrimSpace(v))
+	}
+	return h, nil
+}
+
+// TransportOptions configures NewTransport.
+type TransportOptions struct {
+	// CAFile is a PEM bundle trusted in addition to the system roots.
+	CAFile string
+	// Proxy overrides HTTP(S)_PROXY from the environment.
+	Proxy string
+	// InsecureSkipVerify disables TLS certificate verification.
+	InsecureSkipVerify bool
+}
+
+// Empty reports whether o leaves the default transport unchanged.
+func (o TransportOptions) Empty() bool {
+	return o.CAFile == "" && o.Proxy == "" && !o.InsecureSkipVerify
+}
+
+// NewTransport returns a clone of http.DefaultTransport with o applied.
+func NewTransport(o TransportOptions) (*http.Transport, error) {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("http.DefaultTransport is not an *http.Transport")
+	}
+	t := base.Clone()
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read --ca-file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("--ca-file %s: no PEM certificates found", o.CAFile)
+		}
+		t.TLSClientConfig.RootCAs = pool
+	}
+	if o.InsecureSkipVerify {
+		t.TLSClientConfig.InsecureSkipVerify = true
+	}
+	if o.Proxy != "" {
+		u, err := url.Parse(o.Proxy)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid --proxy %q", o.Proxy)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+	return t, nil
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeCAFile(t *testing.T, srv *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	b := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatalf("write ca: %v", err)
+	}
+	return path
+}
+
+func TestNewTransport_TLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	get := func(opts TransportOptions) error {
+		t.Helper()
+		c := NewClient(srv.URL, "k", 5*time.Second)
+		if !opts.Empty() {
+			tr, err := NewTransport(opts)
+			if err != nil {
+				t.Fatalf("transport: %v", err)
+			}
+			c.HTTPClient.Transport = tr
+		}
+		_, _, err := c.GetJSON(context.Background(), "/x", nil)
+		return err
+	}
+
+	if err := get(TransportOptions{}); err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Fatalf("expected unknown-authority error, got %v", err)
+	}
+	if err := get(TransportOptions{CAFile: writeCAFile(t, srv)}); err != nil {
+		t.Fatalf("ca-file: %v", err)
+	}
+	if err := get(TransportOptions{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("insecure: %v", err)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.pem")
+	_ = os.WriteFile(bad, []byte("nope"), 0o600)
+	if _, err := NewTransport(TransportOptions{CAFile: bad}); err == nil {
+		t.Fatalf("expected no-PEM error")
+	}
+	if _, err := NewTransport(TransportOptions{Proxy: "::"}); err == nil {
+		t.Fatalf("expected invalid proxy error")
+	}
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL.
+		proxied = r.URL.String()
+		_, _ = w.Write([]byte(`{"via":"proxy"}`))
+	}))
+	defer proxy.Close()
+
+	tr, err := NewTransport(TransportOptions{Proxy: proxy.URL})
+	if err != nil {
+		t.Fatalf("transport: %v", err)
+	}
+	c := NewClient("http://api.example.invalid/v2", "k", 5*time.Second)
+	c.HTTPClient.Transport = tr
+	out, _, err := c.GetJSON(context.Background(), "/campaigns", nil)
+	if err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if out.(map[string]any)["via"] != "proxy" || proxied != "http://api.example.invalid/v2/campaigns" {
+		t.Fatalf("out=%v proxied=%q", out, proxied)
+	}
+}
+
+func TestMiddleware_OrderAndHeaders(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(r)
+			})
+		}
+	}
+	h, err := ParseHeaders([]string{"X-Gateway=egress-1", "X-Team = growth"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	c := NewClient(srv.URL, "k", 5*time.Second)
+	c.Use(tag("outer"), WithHeaders(h), tag("inner"))
+	if _, _, err := c.GetJSON(context.Background(), "/x", nil); err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Fatalf("order=%v", order)
+	}
+	if got.Get("X-Gateway") != "egress-1" || got.Get("X-Team") != "growth" || got.Get("Authorization") != "Bearer k" {
+		t.Fatalf("headers=%v", got)
+	}
+
+	if _, err := ParseHeaders([]string{"novalue"}); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
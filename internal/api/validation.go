@@ -0,0 +1,156 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// FieldError is one field-level validation failure reported in a 4xx body.
+type FieldError struct {
+	// Field is the dotted path of the offending field, e.g. "daily_limit" or
+	// "campaign_schedule.schedules.0.name".
+	Field string `json:"field"`
+	// Rule is the failed constraint when the API names it ("required", "minimum", "format").
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// parseAPIErrorDetails extracts field errors from the validation shapes seen in
+// practice: Fastify/AJV "validation" arrays, generic "errors"/"details" lists or
+// maps, and, failing those, a Fastify message like "body/daily_limit must be >= 1".
+func parseAPIErrorDetails(body []byte) []FieldError {
+	var m map[string]any
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil
+	}
+	for _, key := range []string{"validation", "errors", "details", "detail"} {
+		if out := fieldErrorsFrom(m[key]); len(out) > 0 {
+			return out
+		}
+	}
+	if msg, ok := m["message"].(string); ok {
+		if fe, ok := fieldErrorFromMessage(msg); ok {
+			return []FieldError{fe}
+		}
+	}
+	return nil
+}
+
+func fieldErrorsFrom(v any) []FieldError {
+	var out []FieldError
+	switch vv := v.(type) {
+	case []any:
+		for _, it := range vv {
+			if e, ok := it.(map[string]any); ok {
+				if fe, ok := fieldErrorFromEntry(e); ok {
+					out = append(out, fe)
+				}
+			}
+		}
+	case map[string]any:
+		// {"field": "message"} or {"field": ["message", ...]}
+		fields := make([]string, 0, len(vv))
+		for field := range vv {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			switch mm := vv[field].(type) {
+			case string:
+				out = append(out, FieldError{Field: field, Message: mm})
+			case []any:
+				for _, msg := range mm {
+					if s, ok := msg.(string); ok {
+						out = append(out, FieldError{Field: field, Message: s})
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+func fieldErrorFromEntry(e map[string]any) (FieldError, bool) {
+	fe := FieldError{
+		Message: firstString(e, "message", "msg"),
+		Rule:    firstString(e, "keyword", "rule", "type", "code"),
+	}
+	switch {
+	case e["instancePath"] != nil || e["dataPath"] != nil:
+		// AJV: JSON pointer to the parent; "required" names the missing child in params.
+		fe.Field = pointerToPath(firstString(e, "instancePath", "dataPath"))
+		if params, ok := e["params"].(map[string]any); ok {
+			if missing, _ := params["missingProperty"].(string); missing != "" {
+				fe.Field = joinPath(fe.Field, missing)
+			}
+		}
+	case e["loc"] != nil:
+		// Pydantic-style: ["body", "daily_limit"].
+		if loc, ok := e["loc"].([]any); ok {
+			parts := make([]string, 0, len(loc))
+			for i, p := range loc {
+				s := fmt.Sprint(p)
+				if i == 0 && isLocationPrefix(s) {
+					continue
+				}
+				parts = append(parts, s)
+			}
+			fe.Field = strings.Join(parts, ".")
+		}
+	default:
+		fe.Field = firstString(e, "field", "path", "param", "property", "name")
+		fe.Field = strings.Trim(strings.ReplaceAll(fe.Field, "/", "."), ".")
+	}
+	return fe, fe.Field != "" || fe.Message != ""
+}
+
+var (
+	// Fastify: "body/daily_limit must be >= 1", "querystring/limit must be integer".
+	fastifyMessageRe = regexp.MustCompile(`^(?:body|querystring|query|params|headers)(/\S*)? (.+)$`)
+	requiredRe       = regexp.MustCompile(`must have required property '([^']+)'`)
+)
+
+func fieldErrorFromMessage(msg string) (FieldError, bool) {
+	sub := fastifyMessageRe.FindStringSubmatch(msg)
+	if sub == nil {
+		return FieldError{}, false
+	}
+	fe := FieldError{Field: pointerToPath(sub[1]), Message: sub[2]}
+	if req := requiredRe.FindStringSubmatch(sub[2]); req != nil {
+		fe.Field = joinPath(fe.Field, req[1])
+		fe.Rule = "required"
+	}
+	return fe, fe.Field != ""
+}
+
+// pointerToPath turns "/a/b/0" into "a.b.0".
+func pointerToPath(p string) string {
+	return strings.Trim(strings.ReplaceAll(p, "/", "."), ".")
+}
+
+func joinPath(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "." + child
+}
+
+func isLocationPrefix(s string) bool {
+	switch s {
+	case "body", "query", "querystring", "path", "params", "headers":
+		return true
+	}
+	return false
+}
+
+func firstString(m map[string]any, keys ...string) string {
+	for _, k := range keys {
+		if s, ok := m[k].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAPIErrorDetails(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want []FieldError
+	}{
+		{
+			"ajv",
+			`{"message":"body/daily_limit must be >= 1","validation":[{"instancePath":"/daily_limit","keyword":"minimum","message":"must be >= 1"},{"instancePath":"/campaign_schedule","keyword":"required","params":{"missingProperty":"schedules"},"message":"must have required property 'schedules'"}]}`,
+			[]FieldError{
+				{Field: "daily_limit", Rule: "minimum", Message: "must be >= 1"},
+				{Field: "campaign_schedule.schedules", Rule: "required", Message: "must have required property 'schedules'"},
+			},
+		},
+		{
+			"pydantic",
+			`{"detail":[{"loc":["body","email"],"msg":"invalid email","type":"value_error"}]}`,
+			[]FieldError{{Field: "email", Rule: "value_error", Message: "invalid email"}},
+		},
+		{
+			"errors_map",
+			`{"errors":{"name":["is required"],"email":"is invalid"}}`,
+			[]FieldError{{Field: "email", Message: "is invalid"}, {Field: "name", Message: "is required"}},
+		},
+		{
+			"errors_list",
+			`{"errors":[{"field":"lead.email","code":"format","message":"bad"}]}`,
+			[]FieldError{{Field: "lead.email", Rule: "format", Message: "bad"}},
+		},
+		{
+			"fastify_message",
+			`{"statusCode":400,"message":"body must have required property 'name'"}`,
+			[]FieldError{{Field: "name", Rule: "required", Message: "must have required property 'name'"}},
+		},
+		{"plain", `{"message":"campaign not found"}`, nil},
+		{"not_json", `oops`, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parseAPIErrorDetails([]byte(tc.body)); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %#v\nwant %#v", got, tc.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"net/url"
 	"strings"
 
@@ -24,13 +23,13 @@ func newAccountCampaignMappingsGetCmd() *cobra.Command {
 		Short: "Get account campaign mappings (GET /account-campaign-mappings/{email})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "account_campaign_mappings.get", err, nil)
 			}
 			email := strings.TrimSpace(args[0])
 			if email == "" {
-				return printError(cmd, "account_campaign_mappings.get", fmt.Errorf("email is required"), nil)
+				return printError(cmd, "account_campaign_mappings.get", usageErrorf("email is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/account-campaign-mappings/"+url.PathEscape(email), nil)
 			if err != nil {
@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/instantly"
+	"github.com/salmonumbrella/instantly-cli/internal/api"
 )
 
 func newAccountsCmd() *cobra.Command {
@@ -32,6 +36,7 @@ func newAccountsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		search        string
 		status        int
 		provider      int
@@ -43,42 +48,39 @@ func newAccountsListCmd() *cobra.Command {
 		Aliases: []string{"ls"},
 		Short:   "List accounts",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "accounts.list", err, nil)
 			}
 
-			q := url.Values{}
-			if limit > 0 {
-				q.Set("limit", fmt.Sprintf("%d", limit))
-			}
-			if startingAfter != "" {
-				q.Set("starting_after", startingAfter)
-			}
-			if search != "" {
-				q.Set("search", search)
-			}
+			params := instantly.ListAccountsParams{ListParams: instantly.ListParams{
+				Limit:         limit,
+				StartingAfter: startingAfter,
+				Search:        search,
+				Query:         url.Values{},
+			}}
 			if cmd.Flags().Changed("status") {
-				q.Set("status", fmt.Sprintf("%d", status))
+				params.Status = instantly.Ptr(status)
 			}
 			if cmd.Flags().Changed("provider") {
-				q.Set("provider_code", fmt.Sprintf("%d", provider))
+				params.ProviderCode = instantly.Ptr(provider)
 			}
-			if err := applyQueryPairs(q, queryPairs); err != nil {
+			if err := applyQueryPairs(params.Query, queryPairs); err != nil {
 				return printError(cmd, "accounts.list", err, nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/accounts", q)
-			if err != nil {
-				return printError(cmd, "accounts.list", err, metaFrom(meta, nil))
-			}
-
-			return printResult(cmd, "accounts.list", resp, metaFrom(meta, resp))
+			return printList(cmd, "accounts.list", pages, func(ctx context.Context, cursor string) (any, *api.Meta, error) {
+				if cursor != "" {
+					params.StartingAfter = cursor
+				}
+				return sdkPage(sdk.Accounts.List(ctx, params))
+			})
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination (use response next_starting_after)")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringVar(&search, "search", "", "Search accounts")
 	cmd.Flags().IntVar(&status, "status", 0, "Filter by status (1 active, 2 paused, negative error codes)")
 	cmd.Flags().Lookup("status").NoOptDefVal = "1"
@@ -95,21 +97,21 @@ func newAccountsGetCmd() *cobra.Command {
 		Short:   "Get account by email",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "accounts.get", err, nil)
 			}
 
 			email := strings.TrimSpace(args[0])
 			if email == "" {
-				return printError(cmd, "accounts.get", fmt.Errorf("email is required"), nil)
+				return printError(cmd, "accounts.get", usageErrorf("email is required"), nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/accounts/"+url.PathEscape(email), nil)
+			account, meta, err := sdk.Accounts.Get(cmdContext(cmd), email)
 			if err != nil {
 				return printError(cmd, "accounts.get", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "accounts.get", resp, metaFrom(meta, resp))
+			return printResult(cmd, "accounts.get", account.Raw, metaFrom(meta, account.Raw))
 		},
 	}
 	return cmd
@@ -147,7 +149,7 @@ You can either:
 - use flags only for the common fields.
 `),
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "accounts.create", err, nil)
 			}
@@ -202,7 +204,7 @@ You can either:
 
 			emailVal, ok := body["email"].(string)
 			if !ok || strings.TrimSpace(emailVal) == "" {
-				return printError(cmd, "accounts.create", fmt.Errorf("--email is required (or set in --data-json/--data-file)"), nil)
+				return printError(cmd, "accounts.create", usageErrorf("--email is required (or set in --data-json/--data-file)"), nil)
 			}
 
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/accounts", nil, body)
@@ -251,14 +253,14 @@ func newAccountsUpdateCmd() *cobra.Command {
 		Short: "Update account settings (PATCH /accounts/{email})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "accounts.update", err, nil)
 			}
 
 			email := strings.TrimSpace(args[0])
 			if email == "" {
-				return printError(cmd, "accounts.update", fmt.Errorf("email is required"), nil)
+				return printError(cmd, "accounts.update", usageErrorf("email is required"), nil)
 			}
 
 			body := map[string]any{}
@@ -287,7 +289,7 @@ func newAccountsUpdateCmd() *cobra.Command {
 			}
 
 			if len(body) == 0 {
-				return printError(cmd, "accounts.update", fmt.Errorf("no fields to update (provide flags or --data-json/--data-file)"), nil)
+				return printError(cmd, "accounts.update", usageErrorf("no fields to update (provide flags or --data-json/--data-file)"), nil)
 			}
 
 			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/accounts/"+url.PathEscape(email), nil, body)
@@ -328,13 +330,13 @@ func accountBulkEmailActionCmd(use, short, endpoint string) *cobra.Command {
 		Short: short,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "accounts."+use, err, nil)
 			}
 			email := strings.TrimSpace(args[0])
 			if email == "" {
-				return printError(cmd, "accounts."+use, fmt.Errorf("email is required"), nil)
+				return printError(cmd, "accounts."+use, usageErrorf("email is required"), nil)
 			}
 
 			payload := map[string]any{"emails": []string{email}}
@@ -356,15 +358,15 @@ func newAccountsDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "accounts.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "accounts.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "accounts.delete", err, nil)
 			}
 			email := strings.TrimSpace(args[0])
 			if email == "" {
-				return printError(cmd, "accounts.delete", fmt.Errorf("email is required"), nil)
+				return printError(cmd, "accounts.delete", usageErrorf("email is required"), nil)
 			}
 
 			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/accounts/"+url.PathEscape(email), nil)
@@ -382,13 +384,14 @@ func newAccountsAnalyticsDailyCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "analytics-daily",
 		Short: "Daily account analytics (GET /accounts/analytics/daily)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "accounts.analytics_daily", err, nil)
 			}
@@ -402,15 +405,12 @@ func newAccountsAnalyticsDailyCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "accounts.analytics_daily", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/accounts/analytics/daily", q)
-			if err != nil {
-				return printError(cmd, "accounts.analytics_daily", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "accounts.analytics_daily", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "accounts.analytics_daily", "/accounts/analytics/daily", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
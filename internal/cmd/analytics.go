@@ -40,7 +40,7 @@ func newAnalyticsCampaignCmd() *cobra.Command {
 			}
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "analytics.campaign", err, nil)
 			}
@@ -93,7 +93,7 @@ func newAnalyticsDailyCmd() *cobra.Command {
 			}
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "analytics.daily", err, nil)
 			}
@@ -140,7 +140,7 @@ func newAnalyticsWarmupCmd() *cobra.Command {
 		Use:   "warmup",
 		Short: "Get warmup analytics",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "analytics.warmup", err, nil)
 			}
@@ -26,13 +26,14 @@ func newAPIKeysListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List API keys (GET /api-keys)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "api_keys.list", err, nil)
 			}
@@ -46,15 +47,12 @@ func newAPIKeysListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "api_keys.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/api-keys", q)
-			if err != nil {
-				return printError(cmd, "api_keys.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "api_keys.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "api_keys.list", "/api-keys", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -71,9 +69,9 @@ func newAPIKeysCreateCmd() *cobra.Command {
 		Short: "Create API key (POST /api-keys, requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "api_keys.create", fmt.Errorf("refusing to create api key without --confirm"), nil)
+				return printError(cmd, "api_keys.create", errConfirmRequired("refusing to create api key without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "api_keys.create", err, nil)
 			}
@@ -86,7 +84,7 @@ func newAPIKeysCreateCmd() *cobra.Command {
 			}
 			nameVal, ok := body["name"].(string)
 			if !ok || strings.TrimSpace(nameVal) == "" {
-				return printError(cmd, "api_keys.create", fmt.Errorf("--name is required (or set in --data-json/--data-file)"), nil)
+				return printError(cmd, "api_keys.create", usageErrorf("--name is required (or set in --data-json/--data-file)"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/api-keys", nil, body)
 			if err != nil {
@@ -110,15 +108,15 @@ func newAPIKeysDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "api_keys.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "api_keys.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "api_keys.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "api_keys.delete", fmt.Errorf("api_key_id is required"), nil)
+				return printError(cmd, "api_keys.delete", usageErrorf("api_key_id is required"), nil)
 			}
 			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/api-keys/"+url.PathEscape(id), nil)
 			if err != nil {
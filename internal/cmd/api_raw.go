@@ -1,16 +1,17 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/salmonumbrella/instantly-cli/internal/api"
+	"github.com/salmonumbrella/instantly-cli/internal/jsonnum"
 )
 
 var stdinReader io.Reader = os.Stdin
@@ -38,18 +39,19 @@ func newAPIMethodCmd(method string) *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:   method + " <path>",
-		Short: strings.ToUpper(method) + " an arbitrary Instantly API path",
-		Args:  cobra.ExactArgs(1),
+		Use:         method + " <path>",
+		Short:       strings.ToUpper(method) + " an arbitrary Instantly API path",
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{actionAnnotation: strconv.FormatBool(method != "get")},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "api."+method, err, nil)
 			}
 
 			path := strings.TrimSpace(args[0])
 			if path == "" {
-				return printError(cmd, "api."+method, fmt.Errorf("path is required"), nil)
+				return printError(cmd, "api."+method, usageErrorf("path is required"), nil)
 			}
 
 			q := url.Values{}
@@ -60,7 +62,7 @@ func newAPIMethodCmd(method string) *cobra.Command {
 				}
 				k, v, ok := strings.Cut(pair, "=")
 				if !ok || strings.TrimSpace(k) == "" {
-					return printError(cmd, "api."+method, fmt.Errorf("invalid --query %q (expected key=value)", pair), nil)
+					return printError(cmd, "api."+method, usageErrorf("invalid --query %q (expected key=value)", pair), nil)
 				}
 				q.Add(strings.TrimSpace(k), strings.TrimSpace(v))
 			}
@@ -72,8 +74,8 @@ func newAPIMethodCmd(method string) *cobra.Command {
 					return printError(cmd, "api."+method, err, nil)
 				}
 				if raw != nil {
-					if err := json.Unmarshal(raw, &payload); err != nil {
-						return printError(cmd, "api."+method, fmt.Errorf("invalid JSON payload: %w", err), nil)
+					if err := jsonnum.Unmarshal(raw, &payload); err != nil {
+						return printError(cmd, "api."+method, usageErrorf("invalid JSON payload: %w", err), nil)
 					}
 				}
 			}
@@ -90,7 +92,7 @@ func newAPIMethodCmd(method string) *cobra.Command {
 			case "delete":
 				resp, meta, err = client.DeleteJSON(cmdContext(cmd), path, q)
 			default:
-				err = fmt.Errorf("unsupported method %q", method)
+				err = usageErrorf("unsupported method %q", method)
 			}
 			if err != nil {
 				return printError(cmd, "api."+method, err, metaFrom(meta, nil))
@@ -108,7 +110,7 @@ func newAPIMethodCmd(method string) *cobra.Command {
 
 func readJSONInput(data, dataFile string) ([]byte, error) {
 	if strings.TrimSpace(data) != "" && strings.TrimSpace(dataFile) != "" {
-		return nil, fmt.Errorf("--data and --data-file cannot be used together")
+		return nil, usageErrorf("--data and --data-file cannot be used together")
 	}
 	if strings.TrimSpace(data) != "" {
 		return []byte(data), nil
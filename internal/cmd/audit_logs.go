@@ -24,13 +24,14 @@ func newAuditLogsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List audit logs (GET /audit-logs)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "audit_logs.list", err, nil)
 			}
@@ -44,15 +45,12 @@ func newAuditLogsListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "audit_logs.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/audit-logs", q)
-			if err != nil {
-				return printError(cmd, "audit_logs.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "audit_logs.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "audit_logs.list", "/audit-logs", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/salmonumbrella/instantly-cli/internal/api"
+	"github.com/salmonumbrella/instantly-cli/internal/config"
+)
+
+// credentialProfile names the profile a stored credential belongs to.
+func credentialProfile() string {
+	if activeProfile != "" {
+		return activeProfile
+	}
+	if flags.Profile != "" {
+		return flags.Profile
+	}
+	return config.DefaultProfile
+}
+
+func credentialStore() *config.Credentials {
+	return config.CredentialsFor(flags.Config, os.Getenv("INSTANTLY_CREDENTIALS_PASSPHRASE"))
+}
+
+// apiKeySource describes where flags.APIKey came from, for `auth status`.
+func apiKeySource(cmd *cobra.Command) string {
+	switch {
+	case cmd.Flags().Changed("api-key"):
+		return "flag"
+	case strings.TrimSpace(os.Getenv("INSTANTLY_API_KEY")) != "":
+		return "env"
+	case strings.TrimSpace(flags.APIKey) != "":
+		return "profile"
+	}
+	return ""
+}
+
+// fallbackAPIKey is used when no flag, env var or profile api_key set a key:
+// the credential saved by `auth login`, then the profile's api_key_command.
+func fallbackAPIKey(ctx context.Context) (string, error) {
+	if flags.Config != "" {
+		cred, ok, err := credentialStore().Get(credentialProfile())
+		switch {
+		case err != nil && profileAPIKeyCommand == "":
+			return "", &codedError{code: codeAuth, err: err}
+		case err == nil && ok:
+			return cred.APIKey, nil
+		}
+		// An unreadable store (e.g. passphrase-encrypted with the passphrase
+		// unset) must not block api_key_command.
+	}
+	if profileAPIKeyCommand != "" {
+		key, err := apiKeyFromCommand(ctx, profileAPIKeyCommand)
+		if err != nil {
+			return "", &codedError{code: codeAuth, err: err}
+		}
+		return key, nil
+	}
+	return "", api.ErrMissingAPIKey
+}
+
+func newAuthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Log in, check and log out stored API keys",
+		Long: strings.TrimSpace(`
+Store an API key per profile in an encrypted credentials file next to the config
+file, so it stays out of environment variables and shell history.
+
+Keys are used when no --api-key, INSTANTLY_API_KEY or profile api_key is set.
+The file is encrypted with a random key file beside it, or with
+INSTANTLY_CREDENTIALS_PASSPHRASE when that is set at login.
+`),
+	}
+	cmd.AddCommand(newAuthLoginCmd())
+	cmd.AddCommand(newAuthStatusCmd())
+	cmd.AddCommand(newAuthLogoutCmd())
+	return cmd
+}
+
+func newAuthLoginCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "login",
+		Short: "Verify an API key read from stdin and store it for the profile",
+		Example: strings.TrimSpace(`
+  instantly auth login                      # prompts for the key
+  op read op://team/instantly/key | instantly auth login --profile client-a
+`),
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{profileAnnotation: "optional"},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			key, err := readAPIKey(cmd)
+			if err != nil {
+				return printError(cmd, "auth.login", err, nil)
+			}
+			if flags.Config == "" {
+				return printError(cmd, "auth.login", usageErrorf("no config path: set --config or INSTANTLY_CONFIG"), nil)
+			}
+
+			flags.APIKey = key
+			client, err := clientFromFlags(cmdContext(cmd))
+			if err != nil {
+				return printError(cmd, "auth.login", err, nil)
+			}
+			resp, meta, err := client.GetJSON(cmdContext(cmd), "/workspaces/current", nil)
+			if err != nil {
+				return printError(cmd, "auth.login", err, metaFrom(meta, nil))
+			}
+			ws, _ := resp.(map[string]any)
+			cred := config.Credential{
+				APIKey:        key,
+				WorkspaceID:   stringField(ws, "id"),
+				WorkspaceName: stringField(ws, "name"),
+				SavedAt:       time.Now().UTC(),
+			}
+
+			profile := credentialProfile()
+			if err := ensureProfile(profile); err != nil {
+				return printError(cmd, "auth.login", err, nil)
+			}
+			store := credentialStore()
+			if err := store.Put(profile, cred); err != nil {
+				return printError(cmd, "auth.login", err, nil)
+			}
+			return printResult(cmd, "auth.login", map[string]any{
+				"profile":   profile,
+				"key":       config.MaskSecret(key),
+				"workspace": map[string]any{"id": cred.WorkspaceID, "name": cred.WorkspaceName},
+			}, map[string]any{"credentials_path": store.Path})
+		},
+	}
+}
+
+// readAPIKey reads the first line of stdin, prompting without echo on a terminal.
+func readAPIKey(cmd *cobra.Command) (string, error) {
+	var key string
+	if f, ok := stdinReader.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		_, _ = fmt.Fprint(cmd.ErrOrStderr(), "Instantly API key: ")
+		b, err := term.ReadPassword(int(f.Fd()))
+		_, _ = fmt.Fprintln(cmd.ErrOrStderr())
+		if err != nil {
+			return "", fmt.Errorf("read API key: %w", err)
+		}
+		key = string(b)
+	} else {
+		line, err := bufio.NewReader(stdinReader).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("read API key from stdin: %w", err)
+		}
+		key = line
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", usageErrorf("no API key on stdin")
+	}
+	return key, nil
+}
+
+// ensureProfile adds an empty profile to the config file so --profile accepts it.
+func ensureProfile(name string) error {
+	file, err := config.Load(flags.Config)
+	if err != nil {
+		return err
+	}
+	if _, ok := file.Profiles[name]; ok {
+		return nil
+	}
+	if file.Profiles == nil {
+		file.Profiles = map[string]*config.Profile{}
+	}
+	file.Profiles[name] = &config.Profile{}
+	return file.Save(flags.Config)
+}
+
+func newAuthStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the workspace, key name and scopes for the API key in use",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			source := apiKeySource(cmd)
+			client, err := clientFromFlags(cmdContext(cmd))
+			if err != nil {
+				return printError(cmd, "auth.status", err, nil)
+			}
+			if source == "" {
+				source = "credentials"
+				if _, ok, _ := credentialStore().Get(credentialProfile()); !ok {
+					source = "api_key_command"
+				}
+			}
+
+			ctx := cmdContext(cmd)
+			resp, meta, err := client.GetJSON(ctx, "/workspaces/current", nil)
+			if err != nil {
+				return printError(cmd, "auth.status", err, metaFrom(meta, nil))
+			}
+			ws, _ := resp.(map[string]any)
+			out := map[string]any{
+				"profile":   credentialProfile(),
+				"source":    source,
+				"key":       config.MaskSecret(flags.APIKey),
+				"workspace": map[string]any{"id": stringField(ws, "id"), "name": stringField(ws, "name")},
+			}
+
+			// Key name and scopes need a key allowed to list keys; report, don't fail.
+			keys, _, err := client.GetJSON(ctx, "/api-keys", nil)
+			if err != nil {
+				out["api_key_error"] = err.Error()
+			} else if k, _ := matchAPIKey(keys, flags.APIKey); k != nil {
+				out["api_key"] = map[string]any{"id": k["id"], "name": k["name"], "scopes": k["scopes"]}
+			}
+			return printResult(cmd, "auth.status", out, metaFrom(meta, nil))
+		},
+	}
+}
+
+// matchAPIKey finds key in an /api-keys list, by full value or, when the API
+// returns keys masked, by their last four characters. ambiguous reports that
+// several masked keys match, in which case none is returned.
+func matchAPIKey(resp any, key string) (match map[string]any, ambiguous bool) {
+	items, _ := textItems(resp)
+	var masked []map[string]any
+	for _, it := range items {
+		m, ok := it.(map[string]any)
+		if !ok {
+			continue
+		}
+		v := stringField(m, "key")
+		if v == key {
+			return m, false
+		}
+		if len(key) > 4 && strings.Contains(v, "*") && strings.HasSuffix(v, key[len(key)-4:]) {
+			masked = append(masked, m)
+		}
+	}
+	switch len(masked) {
+	case 0:
+		return nil, false
+	case 1:
+		return masked[0], false
+	}
+	return nil, true
+}
+
+func newAuthLogoutCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:         "logout",
+		Short:       "Remove the stored API key for the profile",
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{profileAnnotation: "optional"},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if flags.Config == "" {
+				return printError(cmd, "auth.logout", usageErrorf("no config path: set --config or INSTANTLY_CONFIG"), nil)
+			}
+			profile := credentialProfile()
+			removed, err := credentialStore().Delete(profile)
+			if err != nil {
+				return printError(cmd, "auth.logout", err, nil)
+			}
+			return printResult(cmd, "auth.logout", map[string]any{"profile": profile, "removed": removed}, nil)
+		},
+	}
+}
+
+func stringField(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func withStdin(t *testing.T, s string) {
+	t.Helper()
+	old := stdinReader
+	t.Cleanup(func() { stdinReader = old })
+	stdinReader = strings.NewReader(s)
+}
+
+func TestAuth_LoginStatusLogout(t *testing.T) {
+	var lastAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastAuth = r.Header.Get("Authorization")
+		if lastAuth != "Bearer sk-good-1234" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Invalid API key"}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/workspaces/current":
+			_, _ = w.Write([]byte(`{"id":"w1","name":"Acme"}`))
+		case "/api-keys":
+			_, _ = w.Write([]byte(`{"items":[{"id":"k0","name":"other","key":"****9999"},{"id":"k1","name":"cli","key":"****1234","scopes":["campaigns:read"]}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"j1"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	path := writeConfig(t, "profiles:\n  work:\n    base_url: "+srv.URL+"\n")
+	t.Setenv("INSTANTLY_API_KEY", "")
+	t.Setenv("INSTANTLY_PROFILE", "")
+
+	// A rejected key is not stored.
+	withStdin(t, "sk-bad\n")
+	res := execCLI(t, "--json", "--profile", "work", "auth", "login")
+	if ExitCode(res.Err) != 3 {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), "credentials.enc")); !os.IsNotExist(err) {
+		t.Fatalf("credentials written for a rejected key: %v", err)
+	}
+
+	withStdin(t, "sk-good-1234\n")
+	res = execCLI(t, "--json", "--profile", "work", "auth", "login")
+	if res.Err != nil {
+		t.Fatalf("login: %v %s", res.Err, res.Stdout)
+	}
+	out := mustJSON(t, res.Stdout).(map[string]any)
+	if out["profile"] != "work" || out["key"] != "****1234" || out["workspace"].(map[string]any)["name"] != "Acme" {
+		t.Fatalf("login out=%v", out)
+	}
+
+	// Other commands fall back to the stored key.
+	lastAuth = ""
+	if res := execCLI(t, "--json", "--profile", "work", "jobs", "get", "j1"); res.Err != nil || lastAuth != "Bearer sk-good-1234" {
+		t.Fatalf("fallback: err=%v auth=%q", res.Err, lastAuth)
+	}
+
+	res = execCLI(t, "--json", "--profile", "work", "auth", "status")
+	if res.Err != nil {
+		t.Fatalf("status: %v %s", res.Err, res.Stdout)
+	}
+	out = mustJSON(t, res.Stdout).(map[string]any)
+	key, _ := out["api_key"].(map[string]any)
+	if out["source"] != "credentials" || out["workspace"].(map[string]any)["name"] != "Acme" || key["name"] != "cli" {
+		t.Fatalf("status out=%v", out)
+	}
+
+	res = execCLI(t, "--json", "--profile", "work", "auth", "logout")
+	if res.Err != nil || mustJSON(t, res.Stdout).(map[string]any)["removed"] != true {
+		t.Fatalf("logout: %v %s", res.Err, res.Stdout)
+	}
+	if res := execCLI(t, "--json", "--profile", "work", "jobs", "get", "j1"); ExitCode(res.Err) != 3 {
+		t.Fatalf("after logout: %v", res.Err)
+	}
+}
+
+func TestAuth_LoginCreatesProfile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"w2","name":"Client B"}`))
+	}))
+	t.Cleanup(srv.Close)
+	writeConfig(t, "")
+	t.Setenv("INSTANTLY_API_KEY", "")
+	t.Setenv("INSTANTLY_PROFILE", "")
+
+	withStdin(t, "sk-client-b\n")
+	if res := execCLI(t, "--json", "--base-url", srv.URL, "--profile", "client-b", "auth", "login"); res.Err != nil {
+		t.Fatalf("login: %v %s", res.Err, res.Stdout)
+	}
+	res := execCLI(t, "--json", "config", "list")
+	if res.Err != nil || !strings.Contains(string(res.Stdout), "client-b") {
+		t.Fatalf("config list: %v %s", res.Err, res.Stdout)
+	}
+}
@@ -22,14 +22,16 @@ func TestConvertLineBreaksToHTML(t *testing.T) {
 
 func TestBuildCreateCampaignPayload(t *testing.T) {
 	p := buildCreateCampaignPayload("n", "sub\nj", "body", []string{"a@example.com"}, 1, 2)
-	if p["name"] != "n" {
+	if p.Name != "n" {
 		t.Fatalf("p=%#v", p)
 	}
-	if p["daily_limit"].(int) != 1 || p["email_gap"].(int) != 2 {
+	if p.DailyLimit != 1 || p.EmailGap != 2 {
 		t.Fatalf("p=%#v", p)
 	}
-	seq := p["sequences"].([]any)
-	if len(seq) != 1 {
-		t.Fatalf("seq=%#v", seq)
+	if len(p.Sequences) != 1 {
+		t.Fatalf("seq=%#v", p.Sequences)
+	}
+	if got := p.Sequences[0].Steps[0].Variants[0].Subject; got != "sub j" {
+		t.Fatalf("subject=%q", got)
 	}
 }
@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"regexp"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/instantly"
+	"github.com/salmonumbrella/instantly-cli/internal/api"
 )
 
 func newCampaignsCmd() *cobra.Command {
@@ -34,6 +38,7 @@ func newCampaignsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		search        string
 		queryPairs    []string
 	)
@@ -43,36 +48,33 @@ func newCampaignsListCmd() *cobra.Command {
 		Aliases: []string{"ls"},
 		Short:   "List campaigns",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "campaigns.list", err, nil)
 			}
 
-			q := url.Values{}
-			if limit > 0 {
-				q.Set("limit", fmt.Sprintf("%d", limit))
-			}
-			if startingAfter != "" {
-				q.Set("starting_after", startingAfter)
-			}
-			if search != "" {
-				q.Set("search", search)
-			}
-			if err := applyQueryPairs(q, queryPairs); err != nil {
+			params := instantly.ListCampaignsParams{ListParams: instantly.ListParams{
+				Limit:         limit,
+				StartingAfter: startingAfter,
+				Search:        search,
+				Query:         url.Values{},
+			}}
+			if err := applyQueryPairs(params.Query, queryPairs); err != nil {
 				return printError(cmd, "campaigns.list", err, nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/campaigns", q)
-			if err != nil {
-				return printError(cmd, "campaigns.list", err, metaFrom(meta, nil))
-			}
-
-			return printResult(cmd, "campaigns.list", resp, metaFrom(meta, resp))
+			return printList(cmd, "campaigns.list", pages, func(ctx context.Context, cursor string) (any, *api.Meta, error) {
+				if cursor != "" {
+					params.StartingAfter = cursor
+				}
+				return sdkPage(sdk.Campaigns.List(ctx, params))
+			})
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination (use response next_starting_after)")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringVar(&search, "search", "", "Search by campaign name")
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
@@ -85,20 +87,20 @@ func newCampaignsGetCmd() *cobra.Command {
 		Short:   "Get campaign by ID",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "campaigns.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "campaigns.get", fmt.Errorf("campaign_id is required"), nil)
+				return printError(cmd, "campaigns.get", usageErrorf("campaign_id is required"), nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/campaigns/"+url.PathEscape(id), nil)
+			campaign, meta, err := sdk.Campaigns.Get(cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "campaigns.get", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "campaigns.get", resp, metaFrom(meta, resp))
+			return printResult(cmd, "campaigns.get", campaign.Raw, metaFrom(meta, campaign.Raw))
 		},
 	}
 	return cmd
@@ -119,51 +121,37 @@ func newCampaignsCreateCmd() *cobra.Command {
 		Use:   "create",
 		Short: "Create a campaign (agent-friendly defaults)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "campaigns.create", err, nil)
 			}
 			if strings.TrimSpace(name) == "" {
-				return printError(cmd, "campaigns.create", fmt.Errorf("--name is required"), nil)
+				return printError(cmd, "campaigns.create", usageErrorf("--name is required"), nil)
 			}
 			if strings.TrimSpace(subject) == "" {
-				return printError(cmd, "campaigns.create", fmt.Errorf("--subject is required"), nil)
+				return printError(cmd, "campaigns.create", usageErrorf("--subject is required"), nil)
 			}
 			if strings.TrimSpace(body) == "" {
-				return printError(cmd, "campaigns.create", fmt.Errorf("--body is required"), nil)
+				return printError(cmd, "campaigns.create", usageErrorf("--body is required"), nil)
 			}
 
 			var emailList []string
 			switch strings.TrimSpace(strings.ToLower(senders)) {
 			case "", "auto":
-				if client.DryRun {
+				if flags.DryRun {
 					// In real mode, we would discover eligible senders via GET /accounts.
 					// For --dry-run, keep the shape correct without performing additional calls.
 					emailList = []string{"<auto>"}
 					break
 				}
 				// Auto-pick eligible senders by listing accounts and filtering like the MCP tool.
-				accountsResp, _, err := client.GetJSON(cmdContext(cmd), "/accounts", url.Values{"limit": []string{"100"}})
+				accounts, _, err := sdk.Accounts.List(cmdContext(cmd), instantly.ListAccountsParams{ListParams: instantly.ListParams{Limit: 100}})
 				if err != nil {
 					return printError(cmd, "campaigns.create", fmt.Errorf("auto sender discovery failed: %w", err), nil)
 				}
-				m, ok := accountsResp.(map[string]any)
-				if !ok {
-					return printError(cmd, "campaigns.create", fmt.Errorf("unexpected /accounts response shape"), nil)
-				}
-				items, _ := m["items"].([]any)
-				for _, it := range items {
-					acc, _ := it.(map[string]any)
-					if acc == nil {
-						continue
-					}
-					// status == 1, setup_pending == false, warmup_status == 1
-					status, _ := acc["status"].(float64)
-					setupPending, _ := acc["setup_pending"].(bool)
-					warmupStatus, _ := acc["warmup_status"].(float64)
-					email, _ := acc["email"].(string)
-					if int(status) == 1 && !setupPending && int(warmupStatus) == 1 && email != "" {
-						emailList = append(emailList, email)
+				for _, acc := range accounts.Items {
+					if acc.ReadyToSend() {
+						emailList = append(emailList, acc.Email)
 					}
 					if sendersMax > 0 && len(emailList) >= sendersMax {
 						break
@@ -181,16 +169,17 @@ func newCampaignsCreateCmd() *cobra.Command {
 					}
 				}
 				if len(emailList) == 0 {
-					return printError(cmd, "campaigns.create", fmt.Errorf("--senders must be 'auto' or a comma-separated list of emails"), nil)
+					return printError(cmd, "campaigns.create", usageErrorf("--senders must be 'auto' or a comma-separated list of emails"), nil)
 				}
 			}
 
 			payload := buildCreateCampaignPayload(name, subject, body, emailList, dailyLimit, emailGap)
 
-			resp, meta, err := client.PostJSON(cmdContext(cmd), "/campaigns", nil, payload)
+			campaign, meta, err := sdk.Campaigns.Create(cmdContext(cmd), payload)
 			if err != nil {
 				return printError(cmd, "campaigns.create", err, metaFrom(meta, nil))
 			}
+			resp := campaign.Raw
 			// Always include the request payload used so agents can replay / debug.
 			outMeta := map[string]any{
 				"payload_used": payload,
@@ -211,61 +200,50 @@ func newCampaignsCreateCmd() *cobra.Command {
 	cmd.Flags().IntVar(&sendersMax, "senders-max", 1, "When --senders=auto, pick up to N eligible senders")
 	cmd.Flags().IntVar(&dailyLimit, "daily-limit", 30, "Daily send limit")
 	cmd.Flags().IntVar(&emailGap, "email-gap", 10, "Gap between emails (minutes)")
+	setFlagFields(cmd, "senders", "email_list")
+	setFlagFields(cmd, "subject", "sequences.0.steps.0.variants.0.subject")
+	setFlagFields(cmd, "body", "sequences.0.steps.0.variants.0.body")
 
 	return cmd
 }
 
-func buildCreateCampaignPayload(name, subject, body string, emailList []string, dailyLimit, emailGap int) map[string]any {
+func buildCreateCampaignPayload(name, subject, body string, emailList []string, dailyLimit, emailGap int) instantly.CreateCampaignRequest {
 	subject = regexp.MustCompile(`[\r\n]+`).ReplaceAllString(subject, " ")
 	subject = strings.TrimSpace(subject)
 
 	htmlBody := convertLineBreaksToHTML(body)
 
-	return map[string]any{
-		"name": name,
-		"sequences": []any{
-			map[string]any{
-				"steps": []any{
-					map[string]any{
-						"type":  "email",
-						"delay": 0,
-						"variants": []any{
-							map[string]any{
-								"subject": subject,
-								"body":    htmlBody,
-							},
-						},
-					},
+	return instantly.CreateCampaignRequest{
+		Name: name,
+		Sequences: []instantly.CampaignSequence{{
+			Steps: []instantly.CampaignStep{{
+				Type:     "email",
+				Delay:    0,
+				Variants: []instantly.CampaignVariant{{Subject: subject, Body: htmlBody}},
+			}},
+		}},
+		EmailList:       emailList,
+		OpenTracking:    false,
+		LinkTracking:    false,
+		DailyLimit:      dailyLimit,
+		EmailGap:        emailGap,
+		StopOnReply:     true,
+		StopOnAutoReply: true,
+		CampaignSchedule: instantly.CampaignSchedule{
+			Schedules: []instantly.Schedule{{
+				Name:     "Default Schedule",
+				Timezone: "America/New_York",
+				Timing:   instantly.ScheduleTiming{From: "09:00", To: "17:00"},
+				Days: map[string]bool{
+					"0": false,
+					"1": true,
+					"2": true,
+					"3": true,
+					"4": true,
+					"5": true,
+					"6": false,
 				},
-			},
-		},
-		"email_list":         emailList,
-		"open_tracking":      false,
-		"link_tracking":      false,
-		"daily_limit":        dailyLimit,
-		"email_gap":          emailGap,
-		"stop_on_reply":      true,
-		"stop_on_auto_reply": true,
-		"campaign_schedule": map[string]any{
-			"schedules": []any{
-				map[string]any{
-					"name":     "Default Schedule",
-					"timezone": "America/New_York",
-					"timing": map[string]any{
-						"from": "09:00",
-						"to":   "17:00",
-					},
-					"days": map[string]any{
-						"0": false,
-						"1": true,
-						"2": true,
-						"3": true,
-						"4": true,
-						"5": true,
-						"6": false,
-					},
-				},
-			},
+			}},
 		},
 	}
 }
@@ -320,41 +298,41 @@ func newCampaignsUpdateCmd() *cobra.Command {
 			}
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "campaigns.update", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "campaigns.update", fmt.Errorf("campaign_id is required"), nil)
+				return printError(cmd, "campaigns.update", usageErrorf("campaign_id is required"), nil)
 			}
 
-			body := map[string]any{}
+			req := instantly.UpdateCampaignRequest{}
 			if strings.TrimSpace(name) != "" {
-				body["name"] = name
+				req.Name = instantly.Ptr(name)
 			}
 			if dailyLimitSet {
-				body["daily_limit"] = dailyLimit
+				req.DailyLimit = instantly.Ptr(dailyLimit)
 			}
 			if emailGapSet {
-				body["email_gap"] = emailGap
+				req.EmailGap = instantly.Ptr(emailGap)
 			}
 			if openTrackingSet {
-				body["open_tracking"] = openTracking
+				req.OpenTracking = instantly.Ptr(openTracking)
 			}
 			if linkTrackingSet {
-				body["link_tracking"] = linkTracking
+				req.LinkTracking = instantly.Ptr(linkTracking)
 			}
 
-			if len(body) == 0 {
-				return printError(cmd, "campaigns.update", fmt.Errorf("no fields to update"), nil)
+			if req.Empty() {
+				return printError(cmd, "campaigns.update", usageErrorf("no fields to update"), nil)
 			}
 
-			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/campaigns/"+url.PathEscape(id), nil, body)
+			campaign, meta, err := sdk.Campaigns.Update(cmdContext(cmd), id, req)
 			if err != nil {
 				return printError(cmd, "campaigns.update", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "campaigns.update", resp, metaFrom(meta, resp))
+			return printResult(cmd, "campaigns.update", campaign.Raw, metaFrom(meta, campaign.Raw))
 		},
 	}
 
@@ -368,33 +346,34 @@ func newCampaignsUpdateCmd() *cobra.Command {
 }
 
 func newCampaignsActivateCmd() *cobra.Command {
-	return campaignActionCmd("activate", "Activate campaign", "/campaigns/%s/activate")
+	return campaignActionCmd("activate", "Activate campaign", (*instantly.CampaignsService).Activate)
 }
 
 func newCampaignsPauseCmd() *cobra.Command {
-	return campaignActionCmd("pause", "Pause campaign", "/campaigns/%s/pause")
+	return campaignActionCmd("pause", "Pause campaign", (*instantly.CampaignsService).Pause)
 }
 
-func campaignActionCmd(use, short, endpointFmt string) *cobra.Command {
+type campaignAction func(s *instantly.CampaignsService, ctx context.Context, id string) (*instantly.Campaign, *api.Meta, error)
+
+func campaignActionCmd(use, short string, action campaignAction) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   use + " <campaign_id>",
 		Short: short,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "campaigns."+use, err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "campaigns."+use, fmt.Errorf("campaign_id is required"), nil)
+				return printError(cmd, "campaigns."+use, usageErrorf("campaign_id is required"), nil)
 			}
-			path := fmt.Sprintf(endpointFmt, url.PathEscape(id))
-			resp, meta, err := client.PostJSON(cmdContext(cmd), path, nil, nil)
+			campaign, meta, err := action(sdk.Campaigns, cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "campaigns."+use, err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "campaigns."+use, resp, metaFrom(meta, resp))
+			return printResult(cmd, "campaigns."+use, campaign.Raw, metaFrom(meta, campaign.Raw))
 		},
 	}
 	return cmd
@@ -408,22 +387,22 @@ func newCampaignsDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "campaigns.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "campaigns.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "campaigns.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "campaigns.delete", fmt.Errorf("campaign_id is required"), nil)
+				return printError(cmd, "campaigns.delete", usageErrorf("campaign_id is required"), nil)
 			}
 
-			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/campaigns/"+url.PathEscape(id), nil)
+			campaign, meta, err := sdk.Campaigns.Delete(cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "campaigns.delete", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "campaigns.delete", resp, metaFrom(meta, resp))
+			return printResult(cmd, "campaigns.delete", campaign.Raw, metaFrom(meta, campaign.Raw))
 		},
 	}
 	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm destructive action")
@@ -436,13 +415,13 @@ func newCampaignsSearchByContactCmd() *cobra.Command {
 		Short: "Find campaigns a contact is enrolled in",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "campaigns.search_by_contact", err, nil)
 			}
 			email := strings.TrimSpace(args[0])
 			if email == "" {
-				return printError(cmd, "campaigns.search_by_contact", fmt.Errorf("contact_email is required"), nil)
+				return printError(cmd, "campaigns.search_by_contact", usageErrorf("contact_email is required"), nil)
 			}
 			q := url.Values{"contact_email": []string{email}}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/campaigns/search-by-contact", q)
@@ -461,7 +440,7 @@ func newCampaignsAnalyticsOverviewCmd() *cobra.Command {
 		Use:   "analytics-overview",
 		Short: "Campaign analytics overview (GET /campaigns/analytics/overview)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "campaigns.analytics_overview", err, nil)
 			}
@@ -486,7 +465,7 @@ func newCampaignsAnalyticsStepsCmd() *cobra.Command {
 		Use:   "analytics-steps",
 		Short: "Campaign analytics steps (GET /campaigns/analytics/steps)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "campaigns.analytics_steps", err, nil)
 			}
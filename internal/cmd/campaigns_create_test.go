@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -139,3 +141,44 @@ func TestCampaignsCreate_AutoSenders_Errors(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestCampaignsCreate_AutoSenders_RecordReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts":
+			_, _ = w.Write([]byte(`{"items":[{"email":"yes@example.com","status":1,"setup_pending":false,"warmup_status":1}]}`))
+		case "/campaigns":
+			_, _ = w.Write([]byte(`{"id":"cid"}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	dir := t.TempDir()
+	args := []string{"campaigns", "create", "--name", "n", "--subject", "s", "--body", "b", "--output", "json"}
+
+	res := execCLI(t, append([]string{"--base-url", srv.URL, "--api-key", "secret-key-123456", "--header", "X-Gateway=gw-secret-0001", "--record", dir}, args...)...)
+	if res.Err != nil {
+		t.Fatalf("record err=%v stdout=%q", res.Err, res.Stdout)
+	}
+	srv.Close()
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	for _, f := range files {
+		if b, _ := os.ReadFile(f); strings.Contains(string(b), "gw-secret-0001") {
+			t.Fatalf("%s leaks the --header value:\n%s", f, b)
+		}
+	}
+
+	// Both calls (sender discovery + create) replay offline without an API key.
+	res = execCLI(t, append([]string{"--base-url", srv.URL, "--api-key", "", "--replay", dir}, args...)...)
+	if res.Err != nil {
+		t.Fatalf("replay err=%v stdout=%q", res.Err, res.Stdout)
+	}
+	if m := mustJSON(t, res.Stdout).(map[string]any); m["id"] != "cid" {
+		t.Fatalf("out=%v", m)
+	}
+
+	res = execCLI(t, "jobs", "get", "j1", "--record", dir, "--replay", dir)
+	if res.Err == nil {
+		t.Fatalf("expected --record/--replay conflict")
+	}
+}
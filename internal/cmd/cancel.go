@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+// errDeadline is the cancellation cause when --deadline runs out.
+var errDeadline = errors.New("--deadline exceeded")
+
+// cancelReason returns "deadline" or "signal" once the command context is done, else "".
+func cancelReason(cmd *cobra.Command) string {
+	ctx := cmd.Context()
+	if ctx == nil || ctx.Err() == nil {
+		return ""
+	}
+	if errors.Is(context.Cause(ctx), errDeadline) {
+		return "deadline"
+	}
+	return "signal"
+}
+
+// withCancelled marks meta as cancelled when the command context is done.
+func withCancelled(cmd *cobra.Command, meta map[string]any) map[string]any {
+	reason := cancelReason(cmd)
+	if reason == "" {
+		return meta
+	}
+	if meta == nil {
+		meta = map[string]any{}
+	}
+	meta["cancelled"] = true
+	meta["cancel_reason"] = reason
+	return meta
+}
+
+// printCancelled prints partial results with meta.cancelled=true and returns the
+// cancellation cause so the process still exits non-zero.
+func printCancelled(cmd *cobra.Command, kind string, partial any, meta map[string]any) error {
+	_ = printResult(cmd, kind, partial, withCancelled(cmd, meta))
+	errorReported = true
+	return context.Cause(cmd.Context())
+}
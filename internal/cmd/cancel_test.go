@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// hangingAfterFirstPage serves one page, then blocks until the client gives up.
+func hangingAfterFirstPage() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("starting_after") == "" && r.URL.Path != "/background-jobs/j1" {
+			_, _ = w.Write([]byte(`{"items":[{"id":"1"},{"id":"2"}],"next_starting_after":"c1"}`))
+			return
+		}
+		<-r.Context().Done()
+	}))
+}
+
+func TestDeadline_ListAllReturnsPartialItems(t *testing.T) {
+	srv := hangingAfterFirstPage()
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "--deadline", "100ms", "campaigns", "list", "--all")
+	if res.Err == nil {
+		t.Fatalf("expected cancellation error")
+	}
+	out := mustJSON(t, res.Stdout).(map[string]any)
+	if got := itemIDs(t, out["items"].([]any)); got != "1,2" {
+		t.Fatalf("items=%s", got)
+	}
+	meta := out["meta"].(map[string]any)
+	if meta["cancelled"] != true || meta["cancel_reason"] != "deadline" {
+		t.Fatalf("meta=%#v", meta)
+	}
+	p := meta["pagination"].(map[string]any)
+	if p["has_more"] != true || p["next_starting_after"] != "c1" {
+		t.Fatalf("pagination=%#v", p)
+	}
+}
+
+func TestDeadline_SingleRequestErrorEnvelope(t *testing.T) {
+	srv := hangingAfterFirstPage()
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "--deadline", "50ms", "jobs", "get", "j1")
+	if res.Err == nil {
+		t.Fatalf("expected cancellation error")
+	}
+	out := mustJSON(t, res.Stdout).(map[string]any)
+	if meta, _ := out["meta"].(map[string]any); meta["cancelled"] != true {
+		t.Fatalf("out=%#v", out)
+	}
+}
+
+func TestDeadline_EmailsVerifyStopsPolling(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"verification_status":"pending"}`))
+	}))
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "--deadline", "60ms",
+		"emails", "verify", "--email", "a@example.com", "--max-wait", "10s", "--poll-interval", "5ms")
+	if res.Err == nil {
+		t.Fatalf("expected cancellation error")
+	}
+	out := mustJSON(t, res.Stdout).(map[string]any)
+	if meta := out["meta"].(map[string]any); meta["cancelled"] != true {
+		t.Fatalf("meta=%#v", meta)
+	}
+	info := out["result"].(map[string]any)["_polling_info"].(map[string]any)
+	if info["cancelled"] != true || info["polls_made"].(float64) < 1 {
+		t.Fatalf("polling_info=%#v", info)
+	}
+}
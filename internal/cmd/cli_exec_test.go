@@ -3,9 +3,21 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
+// TestMain lets the test binary stand in for the CLI when a command re-executes
+// itself (--profiles fan-out).
+func TestMain(m *testing.M) {
+	if os.Getenv("INSTANTLY_TEST_RUN_CLI") == "1" {
+		os.Args = append([]string{"instantly"}, os.Args[1:]...)
+		os.Exit(ExitCode(Execute()))
+	}
+	os.Exit(m.Run())
+}
+
 type execResult struct {
 	Stdout []byte
 	Stderr []byte
@@ -14,6 +26,10 @@ type execResult struct {
 
 func execCLI(t *testing.T, args ...string) execResult {
 	t.Helper()
+	if _, ok := os.LookupEnv("INSTANTLY_CONFIG"); !ok {
+		// Keep the developer's own profiles out of tests.
+		t.Setenv("INSTANTLY_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	}
 	c := newRootCmd()
 
 	var out bytes.Buffer
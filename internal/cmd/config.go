@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/salmonumbrella/instantly-cli/internal/config"
+)
+
+// activeProfile is the profile applied to this run ("" when none).
+var activeProfile string
+
+// profileAPIKeyCommand is the active profile's api_key_command, run by
+// clientFromFlags only when no key was given any other way.
+var profileAPIKeyCommand string
+
+// flagEnv names the environment variable that outranks a profile for a flag.
+var flagEnv = map[string]string{
+	"api-key":          "INSTANTLY_API_KEY",
+	"output":           "INSTANTLY_OUTPUT",
+	"rate-limit-state": "INSTANTLY_RATE_LIMIT_STATE",
+	"no-rate-limit":    "INSTANTLY_NO_RATE_LIMIT",
+	"envelope-version": "INSTANTLY_ENVELOPE_VERSION",
+	"jq-lib":           "INSTANTLY_JQ_LIB",
+}
+
+func defaultConfigPath() string {
+	if v := strings.TrimSpace(os.Getenv("INSTANTLY_CONFIG")); v != "" {
+		return v
+	}
+	p, err := config.DefaultPath()
+	if err != nil {
+		return ""
+	}
+	return p
+}
+
+// applyProfile fills flags from the selected profile, keeping flag > env >
+// profile > default: flags given on the command line and flags whose
+// environment variable is set are left alone.
+func applyProfile(cmd *cobra.Command) error {
+	activeProfile, profileAPIKeyCommand = "", ""
+	mode := profileMode(cmd)
+	if flags.Config == "" || mode == "skip" {
+		return nil
+	}
+	file, err := config.Load(flags.Config)
+	if err != nil {
+		return err
+	}
+	name, err := file.Resolve(flags.Profile)
+	if err != nil && mode == "optional" {
+		activeProfile = flags.Profile
+		return nil
+	}
+	if err != nil || name == "" {
+		return usageError(err)
+	}
+	p := file.Profiles[name]
+	activeProfile, profileAPIKeyCommand = name, p.APIKeyCommand
+
+	for _, kv := range p.FlagValues() {
+		f := cmd.Flags().Lookup(kv[0])
+		if f == nil || f.Changed || profileFlagReserved(f) {
+			// Flags this command lacks are skipped, so one profile can carry
+			// defaults for several commands.
+			continue
+		}
+		if env := flagEnv[f.Name]; env != "" && strings.TrimSpace(os.Getenv(env)) != "" {
+			continue
+		}
+		// Value.Set rather than FlagSet.Set: a profile value must not count as
+		// "given on the command line" for conflict checks like --json vs --output.
+		if err := f.Value.Set(kv[1]); err != nil {
+			return usageErrorf("profile %q: invalid %s %q: %w", name, f.Name, kv[1], err)
+		}
+	}
+	return nil
+}
+
+// profileFlagReserved reports flags a profile may not set: the ones that pick
+// the config itself, and --confirm, which must always be a deliberate choice.
+func profileFlagReserved(f *pflag.Flag) bool {
+	switch f.Name {
+	case "config", "profile", "confirm":
+		return true
+	}
+	return false
+}
+
+// apiKeyFromCommand runs the profile's api_key_command and returns its trimmed output.
+func apiKeyFromCommand(ctx context.Context, command string) (string, error) {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	c := exec.CommandContext(ctx, shell, flag, command)
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("api_key_command: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("api_key_command: %w", err)
+	}
+	key := strings.TrimSpace(string(out))
+	if key == "" {
+		return "", fmt.Errorf("api_key_command printed nothing")
+	}
+	return key, nil
+}
+
+// profileAnnotation marks commands that manage profiles: "skip" ignores the
+// config entirely, "optional" applies the profile if it exists but does not
+// fail when --profile names a new one.
+const profileAnnotation = "instantly_profile"
+
+func profileMode(cmd *cobra.Command) string {
+	for c := cmd; c != nil; c = c.Parent() {
+		if v := c.Annotations[profileAnnotation]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "config",
+		Short:       "Manage named profiles in the config file",
+		Annotations: map[string]string{profileAnnotation: "skip"},
+		Long: strings.TrimSpace(`
+Profiles hold per-workspace settings so they need not be repeated on every
+command. The file lives at $XDG_CONFIG_HOME/instantly/config.yaml (override
+with --config or INSTANTLY_CONFIG):
+
+  current_profile: client-a
+  profiles:
+    client-a:
+      api_key_command: op read op://clients/instantly-a/key
+      output: json
+      timeout: 30s
+      max_429_retries: 3
+      flags:
+        cache-ttl: 5m
+
+--profile or INSTANTLY_PROFILE picks a profile for one run; "config use"
+changes the default. Flags beat environment variables, which beat the
+profile, which beats built-in defaults.
+
+get and set act on the selected profile (or "default" if none is selected).
+Keys: api_key, api_key_command, base_url, output, timeout, max_429_retries,
+max_5xx_retries, retry_delay, max_retry_delay, and flags.<flag-name>.
+`),
+	}
+	cmd.AddCommand(newConfigListCmd())
+	cmd.AddCommand(newConfigGetCmd())
+	cmd.AddCommand(newConfigSetCmd())
+	cmd.AddCommand(newConfigUseCmd())
+	return cmd
+}
+
+// configTarget loads the file and names the profile get/set act on.
+func configTarget() (*config.File, string, error) {
+	if flags.Config == "" {
+		return nil, "", usageErrorf("no config path: set --config or INSTANTLY_CONFIG")
+	}
+	file, err := config.Load(flags.Config)
+	if err != nil {
+		return nil, "", err
+	}
+	name := flags.Profile
+	if name == "" {
+		name = file.CurrentProfile
+	}
+	if name == "" {
+		name = config.DefaultProfile
+	}
+	return file, name, nil
+}
+
+func newConfigListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List profiles (API keys masked)",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			file, current, err := configTarget()
+			if err != nil {
+				return printError(cmd, "config.list", err, nil)
+			}
+			items := make([]any, 0, len(file.Profiles))
+			for _, name := range file.Names() {
+				p := file.Profiles[name]
+				item := map[string]any{"name": name, "current": name == current}
+				for _, key := range config.Keys {
+					if v, ok := p.Get(key); ok {
+						if key == "api_key" {
+							v = config.MaskSecret(p.APIKey)
+						}
+						item[key] = v
+					}
+				}
+				if len(p.Flags) > 0 {
+					item["flags"] = p.Flags
+				}
+				items = append(items, item)
+			}
+			return printResult(cmd, "config.list", map[string]any{"items": items}, map[string]any{"path": flags.Config})
+		},
+	}
+}
+
+func newConfigGetCmd() *cobra.Command {
+	var reveal bool
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print one setting of the selected profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, name, err := configTarget()
+			if err != nil {
+				return printError(cmd, "config.get", err, nil)
+			}
+			p, ok := file.Profiles[name]
+			if !ok {
+				return printError(cmd, "config.get", usageErrorf("profile %q not found", name), nil)
+			}
+			v, ok := p.Get(args[0])
+			if !ok {
+				return printError(cmd, "config.get", usageErrorf("%s is not set in profile %q", args[0], name), nil)
+			}
+			if args[0] == "api_key" && !reveal {
+				v = config.MaskSecret(p.APIKey)
+			}
+			return printResult(cmd, "config.get", map[string]any{"profile": name, "key": args[0], "value": v}, nil)
+		},
+	}
+	cmd.Flags().BoolVar(&reveal, "reveal", false, "Print api_key unmasked")
+	return cmd
+}
+
+func newConfigSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set (or, with an empty value, clear) a setting of the selected profile",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, name, err := configTarget()
+			if err != nil {
+				return printError(cmd, "config.set", err, nil)
+			}
+			if file.Profiles == nil {
+				file.Profiles = map[string]*config.Profile{}
+			}
+			p, ok := file.Profiles[name]
+			if !ok {
+				p = &config.Profile{}
+				file.Profiles[name] = p
+			}
+			if err := p.Set(args[0], args[1]); err != nil {
+				return printError(cmd, "config.set", usageError(err), nil)
+			}
+			if err := file.Save(flags.Config); err != nil {
+				return printError(cmd, "config.set", err, nil)
+			}
+			value := args[1]
+			if args[0] == "api_key" && value != "" {
+				value = config.MaskSecret(value)
+			}
+			return printResult(cmd, "config.set", map[string]any{"profile": name, "key": args[0], "value": value}, map[string]any{"path": flags.Config})
+		},
+	}
+}
+
+func newConfigUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <profile>",
+		Short: "Make a profile the default",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, _, err := configTarget()
+			if err != nil {
+				return printError(cmd, "config.use", err, nil)
+			}
+			if _, ok := file.Profiles[args[0]]; !ok {
+				return printError(cmd, "config.use", usageErrorf("profile %q not found (have: %s)", args[0], strings.Join(file.Names(), ", ")), nil)
+			}
+			file.CurrentProfile = args[0]
+			if err := file.Save(flags.Config); err != nil {
+				return printError(cmd, "config.use", err, nil)
+			}
+			return printResult(cmd, "config.use", map[string]any{"current_profile": args[0]}, map[string]any{"path": flags.Config})
+		},
+	}
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("INSTANTLY_CONFIG", path)
+	return path
+}
+
+func authServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"j1","auth":"` + r.Header.Get("Authorization") + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProfile_Precedence(t *testing.T) {
+	srv := authServer(t)
+	writeConfig(t, "current_profile: a\nprofiles:\n"+
+		"  a:\n    api_key: key-a\n    base_url: "+srv.URL+"\n    output: yaml\n"+
+		"  b:\n    api_key_command: echo key-b\n    base_url: "+srv.URL+"\n    output: json\n")
+	t.Setenv("INSTANTLY_API_KEY", "")
+	t.Setenv("INSTANTLY_OUTPUT", "")
+
+	// Profile a fills key, base URL and output.
+	res := execCLI(t, "jobs", "get", "j1")
+	if res.Err != nil || !strings.Contains(string(res.Stdout), "auth: Bearer key-a") {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+
+	// --profile picks b, whose key comes from a command; env beats the profile's output.
+	t.Setenv("INSTANTLY_OUTPUT", "jsonl")
+	res = execCLI(t, "--profile", "b", "jobs", "get", "j1")
+	if res.Err != nil || string(res.Stdout) != `{"auth":"Bearer key-b","id":"j1"}`+"\n" {
+		t.Fatalf("err=%v stdout=%q", res.Err, res.Stdout)
+	}
+
+	// Flags beat env and profile; --json does not conflict with a profile's output.
+	t.Setenv("INSTANTLY_PROFILE", "b")
+	t.Setenv("INSTANTLY_API_KEY", "key-env")
+	res = execCLI(t, "--json", "jobs", "get", "j1")
+	if res.Err != nil || mustJSON(t, res.Stdout).(map[string]any)["auth"] != "Bearer key-env" {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+	res = execCLI(t, "--json", "--api-key", "key-flag", "jobs", "get", "j1")
+	if res.Err != nil || mustJSON(t, res.Stdout).(map[string]any)["auth"] != "Bearer key-flag" {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+
+	res = execCLI(t, "--profile", "missing", "version")
+	if res.Err == nil || ExitCode(res.Err) != 2 {
+		t.Fatalf("err=%v", res.Err)
+	}
+}
+
+func TestProfile_APIKeyCommandFailure(t *testing.T) {
+	writeConfig(t, "profiles:\n  default:\n    api_key_command: echo nope >&2; exit 3\n")
+	t.Setenv("INSTANTLY_API_KEY", "")
+	res := execCLI(t, "--base-url", "http://127.0.0.1:1", "jobs", "get", "j1")
+	if res.Err == nil || ExitCode(res.Err) != 3 || !strings.Contains(res.Err.Error(), "nope") {
+		t.Fatalf("err=%v", res.Err)
+	}
+}
+
+func TestProfile_APIKeyCommandAfterUnreadableCredentials(t *testing.T) {
+	srv := authServer(t)
+	path := writeConfig(t, "profiles:\n  default:\n    api_key_command: echo key-c\n    base_url: "+srv.URL+"\n")
+	t.Setenv("INSTANTLY_API_KEY", "")
+	t.Setenv("INSTANTLY_CREDENTIALS_PASSPHRASE", "")
+	t.Cleanup(func() { activeProfile, profileAPIKeyCommand = "", "" })
+	// A store that cannot be decrypted, as when its passphrase is not set.
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "credentials.enc"), []byte("not-decryptable"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	res := execCLI(t, "--output", "json", "jobs", "get", "j1")
+	if res.Err != nil || mustJSON(t, res.Stdout).(map[string]any)["auth"] != "Bearer key-c" {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+}
+
+func TestFallbackAPIKey_HonorsContext(t *testing.T) {
+	resetFlagsToDefaults()
+	flags.Config = ""
+	old := profileAPIKeyCommand
+	profileAPIKeyCommand = "sleep 5"
+	t.Cleanup(func() { profileAPIKeyCommand = old })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	if _, err := fallbackAPIKey(ctx); err == nil {
+		t.Fatalf("expected error from a cancelled api_key_command")
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Fatalf("api_key_command ignored cancellation (%v)", time.Since(start))
+	}
+}
+
+func TestConfigCommands(t *testing.T) {
+	path := writeConfig(t, "")
+	t.Setenv("INSTANTLY_PROFILE", "")
+
+	mustRun := func(args ...string) map[string]any {
+		t.Helper()
+		res := execCLI(t, append([]string{"--output", "json"}, args...)...)
+		if res.Err != nil {
+			t.Fatalf("%v: err=%v stdout=%s", args, res.Err, res.Stdout)
+		}
+		return mustJSON(t, res.Stdout).(map[string]any)
+	}
+
+	mustRun("config", "set", "api_key", "sk-1234567890")
+	mustRun("config", "set", "--profile", "client-b", "timeout", "45s")
+	mustRun("config", "set", "--profile", "client-b", "flags.cache-ttl", "5m")
+
+	if got := mustRun("config", "get", "api_key"); got["value"] != "****7890" || got["profile"] != "default" {
+		t.Fatalf("got=%#v", got)
+	}
+	if got := mustRun("config", "get", "api_key", "--reveal"); got["value"] != "sk-1234567890" {
+		t.Fatalf("got=%#v", got)
+	}
+
+	mustRun("config", "use", "client-b")
+	list := mustRun("config", "list")["items"].([]any)
+	if len(list) != 2 || list[0].(map[string]any)["name"] != "client-b" || list[0].(map[string]any)["current"] != true {
+		t.Fatalf("list=%#v", list)
+	}
+	if got := mustRun("config", "get", "flags.cache-ttl"); got["value"] != "5m" {
+		t.Fatalf("got=%#v", got)
+	}
+
+	for _, args := range [][]string{
+		{"config", "set", "timeout", "soon"},
+		{"config", "set", "bogus", "1"},
+		{"config", "use", "nope"},
+	} {
+		if res := execCLI(t, args...); res.Err == nil || ExitCode(res.Err) != 2 {
+			t.Fatalf("%v: err=%v", args, res.Err)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(b), "current_profile: client-b") {
+		t.Fatalf("file=%s err=%v", b, err)
+	}
+}
@@ -34,13 +34,14 @@ func newCRMPhoneNumbersListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List phone numbers (GET /crm-actions/phone-numbers)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "crm_actions.phone_numbers.list", err, nil)
 			}
@@ -54,15 +55,12 @@ func newCRMPhoneNumbersListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "crm_actions.phone_numbers.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/crm-actions/phone-numbers", q)
-			if err != nil {
-				return printError(cmd, "crm_actions.phone_numbers.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "crm_actions.phone_numbers.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "crm_actions.phone_numbers.list", "/crm-actions/phone-numbers", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -75,15 +73,15 @@ func newCRMPhoneNumbersDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "crm_actions.phone_numbers.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "crm_actions.phone_numbers.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "crm_actions.phone_numbers.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "crm_actions.phone_numbers.delete", fmt.Errorf("phone_number_id is required"), nil)
+				return printError(cmd, "crm_actions.phone_numbers.delete", usageErrorf("phone_number_id is required"), nil)
 			}
 			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/crm-actions/phone-numbers/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -115,13 +113,14 @@ func newDFYOrdersListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List DFY orders (GET /dfy-email-account-orders)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "dfy_orders.list", err, nil)
 			}
@@ -135,15 +134,12 @@ func newDFYOrdersListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "dfy_orders.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/dfy-email-account-orders", q)
-			if err != nil {
-				return printError(cmd, "dfy_orders.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "dfy_orders.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "dfy_orders.list", "/dfy-email-account-orders", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -159,9 +155,9 @@ func newDFYOrdersCreateCmd() *cobra.Command {
 		Short: "Create a DFY order (POST /dfy-email-account-orders, requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "dfy_orders.create", fmt.Errorf("refusing to create order without --confirm"), nil)
+				return printError(cmd, "dfy_orders.create", errConfirmRequired("refusing to create order without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "dfy_orders.create", err, nil)
 			}
@@ -170,7 +166,7 @@ func newDFYOrdersCreateCmd() *cobra.Command {
 				return printError(cmd, "dfy_orders.create", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "dfy_orders.create", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "dfy_orders.create", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/dfy-email-account-orders", nil, body)
 			if err != nil {
@@ -200,13 +196,14 @@ func newDFYOrdersAccountsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List DFY email accounts (GET /dfy-email-account-orders/accounts)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "dfy_orders.accounts.list", err, nil)
 			}
@@ -220,15 +217,12 @@ func newDFYOrdersAccountsListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "dfy_orders.accounts.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/dfy-email-account-orders/accounts", q)
-			if err != nil {
-				return printError(cmd, "dfy_orders.accounts.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "dfy_orders.accounts.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "dfy_orders.accounts.list", "/dfy-email-account-orders/accounts", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -244,9 +238,9 @@ func newDFYOrdersAccountsCancelCmd() *cobra.Command {
 		Short: "Cancel DFY email accounts (POST /dfy-email-account-orders/accounts/cancel, requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "dfy_orders.accounts.cancel", fmt.Errorf("refusing to cancel without --confirm"), nil)
+				return printError(cmd, "dfy_orders.accounts.cancel", errConfirmRequired("refusing to cancel without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "dfy_orders.accounts.cancel", err, nil)
 			}
@@ -255,7 +249,7 @@ func newDFYOrdersAccountsCancelCmd() *cobra.Command {
 				return printError(cmd, "dfy_orders.accounts.cancel", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "dfy_orders.accounts.cancel", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "dfy_orders.accounts.cancel", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/dfy-email-account-orders/accounts/cancel", nil, body)
 			if err != nil {
@@ -320,9 +314,9 @@ func dfyOrdersDomainsPostCmd(use, op, short, endpoint string) *cobra.Command {
 		Short: short,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, op, fmt.Errorf("refusing to run without --confirm"), nil)
+				return printError(cmd, op, errConfirmRequired("refusing to run without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, op, err, nil)
 			}
@@ -331,7 +325,7 @@ func dfyOrdersDomainsPostCmd(use, op, short, endpoint string) *cobra.Command {
 				return printError(cmd, op, err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, op, fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, op, usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), endpoint, nil, body)
 			if err != nil {
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/internal/outfmt"
+)
+
+// printDelimited renders resp as csv/tsv: one row per list item (or a single row
+// for an object), nested objects flattened to dotted column names. --jq, if set,
+// runs first; --fields then projects each record and picks and orders columns.
+func printDelimited(cmd *cobra.Command, kind string, resp any, meta map[string]any, mode outfmt.Mode) error {
+	value := resp
+	var columns []string
+	exprs := []string{strings.TrimSpace(flags.JQ)}
+	if strings.TrimSpace(flags.Fields) != "" {
+		fields, err := parseFields(flags.Fields)
+		if err != nil {
+			return printError(cmd, kind, err, meta)
+		}
+		exprs = append(exprs, buildFieldsQuery(fields))
+		columns = fieldColumns(fields)
+	}
+	for _, expr := range exprs {
+		if expr == "" {
+			continue
+		}
+		v, err := applyJQ(value, expr)
+		if err != nil {
+			return printError(cmd, kind, err, meta)
+		}
+		value = v
+	}
+
+	items, isList := textItems(value)
+	if !isList {
+		items = []any{value}
+	}
+	records := make([]map[string]any, len(items))
+	for i, it := range items {
+		records[i] = flattenRecord(it)
+	}
+	if columns == nil {
+		columns = unionColumns(records)
+	}
+
+	rows := make([][]string, len(items))
+	for i, rec := range records {
+		row := make([]string, len(columns))
+		for j, c := range columns {
+			v, ok := rec[c]
+			if !ok {
+				// A --fields path may name a whole nested object.
+				v, _ = lookupPath(items[i], c)
+			}
+			row[j] = csvCell(v)
+		}
+		rows[i] = row
+	}
+	return outfmt.PrintDelimited(cmd.OutOrStdout(), mode, columns, rows, flags.NoHeaders)
+}
+
+// flattenRecord maps dotted paths to leaf values: {"a":{"b":1}} -> {"a.b":1}.
+// Arrays are leaves (joined by csvCell); a non-object becomes {"value": v}.
+func flattenRecord(v any) map[string]any {
+	out := map[string]any{}
+	m, ok := v.(map[string]any)
+	if !ok {
+		out["value"] = v
+		return out
+	}
+	var walk func(prefix string, m map[string]any)
+	walk = func(prefix string, m map[string]any) {
+		for k, v := range m {
+			if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+				walk(prefix+k+".", nested)
+				continue
+			}
+			out[prefix+k] = v
+		}
+	}
+	walk("", m)
+	return out
+}
+
+// unionColumns returns every column across records, sorted so the header is the
+// same whichever page or item introduced a column.
+func unionColumns(records []map[string]any) []string {
+	seen := map[string]bool{}
+	var cols []string
+	for _, rec := range records {
+		for k := range rec {
+			if !seen[k] {
+				seen[k] = true
+				cols = append(cols, k)
+			}
+		}
+	}
+	sort.Strings(cols)
+	return cols
+}
+
+// csvCell formats a value for a spreadsheet cell. Unlike text output, strings are
+// kept verbatim (the csv writer quotes them) and scalar arrays join with "; ".
+func csvCell(v any) string {
+	switch vv := v.(type) {
+	case string:
+		return vv
+	case []any:
+		parts := make([]string, 0, len(vv))
+		for _, it := range vv {
+			if !isScalar(it) {
+				return compactJSON(v)
+			}
+			parts = append(parts, csvCell(it))
+		}
+		return strings.Join(parts, "; ")
+	case map[string]any:
+		if len(vv) == 0 {
+			return ""
+		}
+		return compactJSON(v)
+	default:
+		return formatTextValue(v)
+	}
+}
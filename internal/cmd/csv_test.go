@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCSVOutput_FlattensAcrossPages(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"items":[{"id":"1","email":"a@x.com","payload":{"city":"Oslo"},"tags":["vip","new"]}],"next_starting_after":"c1"}`,
+		"c1": `{"items":[{"id":"2","email":"b@x.com","payload":{"city":"Rome","zip":"001"}}]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(pages[r.URL.Query().Get("starting_after")]))
+	}))
+	t.Cleanup(srv.Close)
+
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "csv", "accounts", "list", "--all")
+	if res.Err != nil {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+	want := "email,id,payload.city,payload.zip,tags\n" +
+		"a@x.com,1,Oslo,,vip; new\n" +
+		"b@x.com,2,Rome,001,\n"
+	if string(res.Stdout) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", res.Stdout, want)
+	}
+}
+
+func TestTSVOutput_FieldsSelectColumns(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[{"id":"1","email":"a@x.com","payload":{"city":"Oslo"}}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "tsv", "--fields", "payload.city,email,payload", "accounts", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+	want := "payload.city\temail\tpayload\n" +
+		"Oslo\ta@x.com\t\"{\"\"city\"\":\"\"Oslo\"\"}\"\n"
+	if string(res.Stdout) != want {
+		t.Fatalf("got %q want %q", res.Stdout, want)
+	}
+}
+
+func TestCSVOutput_FieldAliasesNameColumns(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":[{"campaign":{"id":"c1"},"lead":{"id":"l1"},"tags":[{"name":"a"},{"name":"b"}]}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "csv",
+		"--fields", "campaign.id:campaign_id,lead.id:lead_id,tags[].name:tags", "api", "get", "/x")
+	if res.Err != nil {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+	if want := "campaign_id,lead_id,tags\nc1,l1,a; b\n"; string(res.Stdout) != want {
+		t.Fatalf("got %q want %q", res.Stdout, want)
+	}
+}
+
+func TestCSVOutput_ErrorsGoToStderr(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"nope"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "csv", "campaigns", "get", "x")
+	if res.Err == nil {
+		t.Fatalf("expected error")
+	}
+	if len(res.Stdout) != 0 || len(res.Stderr) == 0 {
+		t.Fatalf("stdout=%q stderr=%q", res.Stdout, res.Stderr)
+	}
+}
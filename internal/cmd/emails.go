@@ -1,12 +1,15 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/instantly"
+	"github.com/salmonumbrella/instantly-cli/internal/api"
 )
 
 func newEmailsCmd() *cobra.Command {
@@ -33,6 +36,7 @@ func newEmailsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		search        string
 		campaignID    string
 		eaccount      string
@@ -51,44 +55,40 @@ func newEmailsListCmd() *cobra.Command {
 			}
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "emails.list", err, nil)
 			}
 
-			q := url.Values{}
-			if limit > 0 {
-				q.Set("limit", fmt.Sprintf("%d", limit))
-			}
-			if startingAfter != "" {
-				q.Set("starting_after", startingAfter)
-			}
-			if search != "" {
-				q.Set("search", search)
-			}
-			if campaignID != "" {
-				q.Set("campaign_id", campaignID)
-			}
-			if eaccount != "" {
-				q.Set("eaccount", eaccount)
+			params := instantly.ListEmailsParams{
+				ListParams: instantly.ListParams{
+					Limit:         limit,
+					StartingAfter: startingAfter,
+					Search:        search,
+					Query:         url.Values{},
+				},
+				CampaignID: campaignID,
+				EAccount:   eaccount,
 			}
 			if isUnreadSet {
-				q.Set("is_unread", fmt.Sprintf("%v", isUnread))
+				params.IsUnread = instantly.Ptr(isUnread)
 			}
-			if err := applyQueryPairs(q, queryPairs); err != nil {
+			if err := applyQueryPairs(params.Query, queryPairs); err != nil {
 				return printError(cmd, "emails.list", err, nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/emails", q)
-			if err != nil {
-				return printError(cmd, "emails.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "emails.list", resp, metaFrom(meta, resp))
+			return printList(cmd, "emails.list", pages, func(ctx context.Context, cursor string) (any, *api.Meta, error) {
+				if cursor != "" {
+					params.StartingAfter = cursor
+				}
+				return sdkPage(sdk.Emails.List(ctx, params))
+			})
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination (use response next_starting_after)")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringVar(&search, "search", "", "Search emails")
 	cmd.Flags().StringVar(&campaignID, "campaign-id", "", "Filter by campaign ID")
 	cmd.Flags().StringVar(&eaccount, "eaccount", "", "Filter by sender account")
@@ -105,19 +105,19 @@ func newEmailsGetCmd() *cobra.Command {
 		Short:   "Get an email by ID",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "emails.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "emails.get", fmt.Errorf("email_id is required"), nil)
+				return printError(cmd, "emails.get", usageErrorf("email_id is required"), nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/emails/"+url.PathEscape(id), nil)
+			email, meta, err := sdk.Emails.Get(cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "emails.get", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "emails.get", resp, metaFrom(meta, resp))
+			return printResult(cmd, "emails.get", email.Raw, metaFrom(meta, email.Raw))
 		},
 	}
 	return cmd
@@ -128,7 +128,7 @@ func newEmailsUnreadCountCmd() *cobra.Command {
 		Use:   "unread-count",
 		Short: "Count unread emails",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "emails.unread_count", err, nil)
 			}
@@ -158,20 +158,20 @@ func newEmailsReplyCmd() *cobra.Command {
 		Short: "Reply to an email thread (requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "emails.reply", fmt.Errorf("refusing to send email without --confirm"), nil)
+				return printError(cmd, "emails.reply", errConfirmRequired("refusing to send email without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "emails.reply", err, nil)
 			}
 			if strings.TrimSpace(replyTo) == "" {
-				return printError(cmd, "emails.reply", fmt.Errorf("--reply-to is required"), nil)
+				return printError(cmd, "emails.reply", usageErrorf("--reply-to is required"), nil)
 			}
 			if strings.TrimSpace(eaccount) == "" {
-				return printError(cmd, "emails.reply", fmt.Errorf("--eaccount is required"), nil)
+				return printError(cmd, "emails.reply", usageErrorf("--eaccount is required"), nil)
 			}
 			if strings.TrimSpace(subject) == "" {
-				return printError(cmd, "emails.reply", fmt.Errorf("--subject is required"), nil)
+				return printError(cmd, "emails.reply", usageErrorf("--subject is required"), nil)
 			}
 
 			// Payload shape (matches the MCP server): body is an object with optional html/text.
@@ -187,7 +187,7 @@ func newEmailsReplyCmd() *cobra.Command {
 				bodyObj["text"] = body
 			}
 			if len(bodyObj) == 0 {
-				return printError(cmd, "emails.reply", fmt.Errorf("provide --text or --html (or legacy --body)"), nil)
+				return printError(cmd, "emails.reply", usageErrorf("provide --text or --html (or legacy --body)"), nil)
 			}
 
 			payload := map[string]any{
@@ -228,12 +228,12 @@ func newEmailsVerifyCmd() *cobra.Command {
 		Use:   "verify",
 		Short: "Verify an email (polls until final status by default)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "emails.verify", err, nil)
 			}
 			if strings.TrimSpace(email) == "" {
-				return printError(cmd, "emails.verify", fmt.Errorf("--email is required"), nil)
+				return printError(cmd, "emails.verify", usageErrorf("--email is required"), nil)
 			}
 			if maxWait <= 0 {
 				maxWait = 45 * time.Second
@@ -258,10 +258,23 @@ func newEmailsVerifyCmd() *cobra.Command {
 			if status == "pending" && !skipPolling && maxWait > 0 {
 				start := time.Now()
 				polls := 0
+				ctx := cmdContext(cmd)
 				for time.Since(start) < maxWait {
-					time.Sleep(pollInterval)
+					select {
+					case <-ctx.Done():
+						// Interrupted (signal or --deadline): report the last known status.
+						if m, ok := resp.(map[string]any); ok {
+							m["_polling_info"] = map[string]any{
+								"polls_made":         polls,
+								"total_time_seconds": time.Since(start).Seconds(),
+								"cancelled":          true,
+							}
+						}
+						return printCancelled(cmd, "emails.verify", resp, metaFrom(meta, resp))
+					case <-time.After(pollInterval):
+					}
 					polls++
-					pollResp, _, pollErr := client.GetJSON(cmdContext(cmd), "/email-verification/"+url.PathEscape(email), nil)
+					pollResp, _, pollErr := client.GetJSON(ctx, "/email-verification/"+url.PathEscape(email), nil)
 					if pollErr != nil {
 						continue
 					}
@@ -310,13 +323,13 @@ func newEmailsMarkThreadReadCmd() *cobra.Command {
 		Short: "Mark an email thread as read",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "emails.mark_thread_read", err, nil)
 			}
 			threadID := strings.TrimSpace(args[0])
 			if threadID == "" {
-				return printError(cmd, "emails.mark_thread_read", fmt.Errorf("thread_id is required"), nil)
+				return printError(cmd, "emails.mark_thread_read", usageErrorf("thread_id is required"), nil)
 			}
 
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/emails/threads/"+url.PathEscape(threadID)+"/mark-as-read", nil, nil)
@@ -340,9 +353,9 @@ func newEmailsForwardCmd() *cobra.Command {
 		Short: "Forward an email (POST /emails/forward, requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "emails.forward", fmt.Errorf("refusing to forward email without --confirm"), nil)
+				return printError(cmd, "emails.forward", errConfirmRequired("refusing to forward email without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "emails.forward", err, nil)
 			}
@@ -351,7 +364,7 @@ func newEmailsForwardCmd() *cobra.Command {
 				return printError(cmd, "emails.forward", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "emails.forward", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "emails.forward", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/emails/forward", nil, body)
 			if err != nil {
@@ -376,20 +389,20 @@ func newEmailsUpdateCmd() *cobra.Command {
 		Short: "Update email (PATCH /emails/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "emails.update", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "emails.update", fmt.Errorf("email_id is required"), nil)
+				return printError(cmd, "emails.update", usageErrorf("email_id is required"), nil)
 			}
 			body, err := readJSONObjectInput(dataJSON, dataFile)
 			if err != nil {
 				return printError(cmd, "emails.update", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "emails.update", fmt.Errorf("provide --data-json or --data-file with at least one field"), nil)
+				return printError(cmd, "emails.update", usageErrorf("provide --data-json or --data-file with at least one field"), nil)
 			}
 			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/emails/"+url.PathEscape(id), nil, body)
 			if err != nil {
@@ -411,15 +424,15 @@ func newEmailsDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "emails.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "emails.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "emails.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "emails.delete", fmt.Errorf("email_id is required"), nil)
+				return printError(cmd, "emails.delete", usageErrorf("email_id is required"), nil)
 			}
 			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/emails/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/internal/agentfmt"
+	"github.com/salmonumbrella/instantly-cli/internal/api"
+)
+
+// commandStart is when the running command began, for envelope timing.
+var commandStart = time.Now()
+
+// defaultEnvelopeVersion honors INSTANTLY_ENVELOPE_VERSION so agents can pin
+// the v1 shape without touching every invocation.
+func defaultEnvelopeVersion() int {
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("INSTANTLY_ENVELOPE_VERSION"))); err == nil {
+		return v
+	}
+	return agentfmt.SchemaVersion
+}
+
+func validateEnvelopeVersion(v int) error {
+	if v != 1 && v != agentfmt.SchemaVersion {
+		return usageErrorf("invalid --envelope-version %d (expected 1 or %d)", v, agentfmt.SchemaVersion)
+	}
+	return nil
+}
+
+// envelope wraps cmd's response in the agent envelope selected by --envelope-version.
+func envelope(cmd *cobra.Command, kind string, resp any, meta map[string]any) any {
+	if flags.EnvelopeVersion == 1 {
+		return agentfmt.Envelope(kind, resp, meta)
+	}
+	// Typed responses (version, schema) should classify like the JSON they encode to.
+	if v, err := normalizeForJQ(resp); err == nil {
+		resp = v
+	}
+	return agentfmt.EnvelopeV2(kind, resp, meta, agentfmt.Options{
+		Action:   isActionCommand(cmd),
+		Warnings: envelopeWarnings(meta),
+		Timing: &agentfmt.Timing{
+			StartedAt:  commandStart.UTC().Format(time.RFC3339Nano),
+			DurationMS: time.Since(commandStart).Milliseconds(),
+		},
+	})
+}
+
+// actionAnnotation marks a command outside commandScopes (the raw api methods)
+// as an action: "true" or "false".
+const actionAnnotation = "instantly_action"
+
+// isActionCommand reports whether cmd changes state without creating or
+// reading a resource. It goes by what the command declares, not the HTTP
+// method, since reads such as leads list are POSTs: an actionAnnotation, else
+// any commandScopes scope beyond :read on a command other than create/update.
+func isActionCommand(cmd *cobra.Command) bool {
+	if v, ok := cmd.Annotations[actionAnnotation]; ok {
+		return v == "true"
+	}
+	if name := cmd.Name(); name == "create" || name == "update" {
+		return false
+	}
+	return slices.ContainsFunc(commandScopes[scopeKey(cmd)], func(s string) bool {
+		return !strings.HasSuffix(s, ":read")
+	})
+}
+
+// envelopeWarnings turns noteworthy meta into human-readable warnings.
+func envelopeWarnings(meta map[string]any) []string {
+	var out []string
+	if flags.DryRun {
+		out = append(out, "dry run: no request was sent")
+	}
+	if p, ok := meta["pagination"].(map[string]any); ok && p["truncated"] == true {
+		w := "results truncated by --max-items"
+		if next, _ := p["next_starting_after"].(string); next != "" {
+			w += "; resume with --starting-after " + next
+		}
+		out = append(out, w)
+	}
+	if id, ok := meta["idempotency"].(*api.IdempotencyInfo); ok && id.Reused {
+		out = append(out, "reused idempotency key "+id.Key+" from an earlier attempt; the server may return the original result")
+	}
+	if results, ok := meta["workspaces"].([]*fanOutResult); ok {
+		var failed []string
+		for _, r := range results {
+			if r.Error != nil {
+				failed = append(failed, fmt.Sprintf("%s (%s)", r.Profile, r.Error.Code))
+			}
+		}
+		if len(failed) > 0 {
+			out = append(out, fmt.Sprintf("%d of %d profiles failed: %s", len(failed), len(results), strings.Join(failed, ", ")))
+		}
+	}
+	if meta["cancelled"] == true {
+		out = append(out, fmt.Sprintf("cancelled (%v): results are partial", meta["cancel_reason"]))
+	}
+	return out
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEnvelopeV2_ListAndVersionPin(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":[{"id":"c1"}],"next_starting_after":"c1"}`))
+	}))
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "campaigns", "list", "--max-items", "1")
+	if res.Err != nil {
+		t.Fatalf("err=%v", res.Err)
+	}
+	got := mustJSON(t, res.Stdout).(map[string]any)
+	if got["schema_version"] != float64(2) || got["type"] != "list" || got["kind"] != "campaigns.list" {
+		t.Fatalf("got=%#v", got)
+	}
+	if _, ok := got["timing"].(map[string]any)["duration_ms"]; !ok {
+		t.Fatalf("timing=%#v", got["timing"])
+	}
+	warnings := got["warnings"].([]any)
+	if len(warnings) != 1 || !strings.Contains(warnings[0].(string), "--starting-after c1") {
+		t.Fatalf("warnings=%#v", warnings)
+	}
+
+	res = execCLI(t, "--base-url", srv.URL, "--api-key", "k", "--envelope-version", "1", "campaigns", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v", res.Err)
+	}
+	got = mustJSON(t, res.Stdout).(map[string]any)
+	if _, ok := got["schema_version"]; ok || got["items"] == nil {
+		t.Fatalf("v1 got=%#v", got)
+	}
+}
+
+func TestEnvelopeV2_Action(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"w1"}`))
+	}))
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "webhooks", "delete", "w1", "--confirm")
+	if res.Err != nil {
+		t.Fatalf("err=%v", res.Err)
+	}
+	got := mustJSON(t, res.Stdout).(map[string]any)
+	if got["type"] != "action" || got["result"].(map[string]any)["id"] != "w1" {
+		t.Fatalf("got=%#v", got)
+	}
+}
+
+func TestEnvelopeV2_PostReadIsNotAction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method=%s", r.Method)
+		}
+		_, _ = w.Write([]byte(`{"aggregate_data":{"a@x.com":{"sent":3}}}`))
+	}))
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "analytics", "warmup", "--email", "a@x.com")
+	if res.Err != nil {
+		t.Fatalf("err=%v", res.Err)
+	}
+	if got := mustJSON(t, res.Stdout).(map[string]any); got["type"] != "item" {
+		t.Fatalf("got=%#v", got)
+	}
+
+	res = execCLI(t, "--base-url", srv.URL, "--api-key", "k", "api", "post", "/accounts/warmup-analytics")
+	if res.Err != nil {
+		t.Fatalf("err=%v", res.Err)
+	}
+	if got := mustJSON(t, res.Stdout).(map[string]any); got["type"] != "action" {
+		t.Fatalf("raw post got=%#v", got)
+	}
+}
+
+func TestEnvelopeVersion_Invalid(t *testing.T) {
+	t.Setenv("INSTANTLY_ENVELOPE_VERSION", "3")
+	res := execCLI(t, "version")
+	if res.Err == nil || ExitCode(res.Err) != 2 {
+		t.Fatalf("err=%v", res.Err)
+	}
+}
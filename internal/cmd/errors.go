@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/salmonumbrella/instantly-cli/internal/api"
+)
+
+// errorCode classifies a failure so agents can branch without matching messages.
+type errorCode string
+
+const (
+	codeInternal             errorCode = "internal"
+	codeUsage                errorCode = "usage"
+	codeAuth                 errorCode = "auth"
+	codeForbidden            errorCode = "forbidden"
+	codeNotFound             errorCode = "not_found"
+	codeValidation           errorCode = "validation"
+	codeConfirmationRequired errorCode = "confirmation_required"
+	codeRateLimited          errorCode = "rate_limited"
+	codeServer               errorCode = "server"
+	codeNetwork              errorCode = "network"
+	codeTimeout              errorCode = "timeout"
+	codeCancelled            errorCode = "cancelled"
+	codeMissingScope         errorCode = "missing_scope"
+	codeDecode               errorCode = "decode"
+	codePartialFailure       errorCode = "partial_failure"
+)
+
+// errorClass documents one code: its exit status and whether retrying can help.
+type errorClass struct {
+	Code        errorCode `json:"code"`
+	ExitCode    int       `json:"exit_code"`
+	Retryable   bool      `json:"retryable"`
+	Description string    `json:"description"`
+}
+
+// errorClasses is the full taxonomy, in exit-code order; `schema` prints it.
+var errorClasses = []errorClass{
+	{codeInternal, 1, false, "Unexpected failure outside the other classes, such as an unreadable file"},
+	{codeUsage, 2, false, "Invalid flags, arguments or input, caught before any request is sent"},
+	{codeAuth, 3, false, "Missing or rejected API key (HTTP 401)"},
+	{codeForbidden, 4, false, "API key lacks permission for this operation (HTTP 403)"},
+	{codeNotFound, 5, false, "Resource does not exist (HTTP 404)"},
+	{codeValidation, 6, false, "API rejected the request payload or parameters (other HTTP 4xx)"},
+	{codeConfirmationRequired, 7, false, "Destructive or sending command run without --confirm"},
+	{codeRateLimited, 8, true, "Rate limited (HTTP 429); wait retry_after_ms when present"},
+	{codeServer, 9, true, "Server error (HTTP 5xx)"},
+	{codeNetwork, 10, true, "Connection, DNS or TLS failure before a response arrived"},
+	{codeTimeout, 11, true, "--timeout or --deadline ran out (HTTP 408 included)"},
+	{codeMissingScope, 12, false, "--preflight-scopes found the API key lacks scopes the command needs; missing_scopes lists them"},
+	{codeDecode, 13, false, "A successful response whose body could not be parsed; retrying rarely helps"},
+	{codePartialFailure, 14, false, "--profiles/--all-profiles printed results but some profiles failed; meta.workspaces has each error"},
+	{codeCancelled, 130, false, "Interrupted by SIGINT/SIGTERM"},
+}
+
+func classOf(code errorCode) errorClass {
+	for _, c := range errorClasses {
+		if c.Code == code {
+			return c
+		}
+	}
+	return errorClasses[0]
+}
+
+// codedError tags an error raised by the CLI itself with its code.
+type codedError struct {
+	code errorCode
+	err  error
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+
+func (e *codedError) Unwrap() error { return e.err }
+
+// usageError tags err as a usage error: a flag, argument or input the user can
+// fix. Errors that already classify as something more specific are returned as-is.
+func usageError(err error) error {
+	if err == nil || classifyError(err).Code != codeInternal {
+		return err
+	}
+	return &codedError{code: codeUsage, err: err}
+}
+
+func usageErrorf(format string, args ...any) error {
+	return usageError(fmt.Errorf(format, args...))
+}
+
+// errConfirmRequired is returned by commands that refuse to run without --confirm.
+func errConfirmRequired(msg string) error {
+	return &codedError{code: codeConfirmationRequired, err: errors.New(msg)}
+}
+
+// errorInfo is the classification of one error.
+type errorInfo struct {
+	Code       errorCode
+	Retryable  bool
+	RetryAfter time.Duration
+}
+
+// classifyError maps err to the taxonomy. Bad flags and arguments are tagged
+// with usageError where they are caught; anything unrecognised is internal.
+func classifyError(err error) errorInfo {
+	info := func(code errorCode) errorInfo { return errorInfo{Code: code, Retryable: classOf(code).Retryable} }
+
+	var coded *codedError
+	var apiErr *api.APIError
+	var decodeErr *api.DecodeError
+	var netErr net.Error
+	var urlErr *url.Error
+	switch {
+	case errors.As(err, &coded):
+		return info(coded.code)
+	case errors.Is(err, api.ErrMissingAPIKey):
+		return info(codeAuth)
+	case errors.Is(err, context.Canceled):
+		return info(codeCancelled)
+	case errors.Is(err, errDeadline), errors.Is(err, context.DeadlineExceeded):
+		return info(codeTimeout)
+	case errors.As(err, &apiErr):
+		out := info(statusCode(apiErr.Status))
+		out.RetryAfter = apiErr.RetryAfter
+		return out
+	case errors.As(err, &decodeErr):
+		return info(codeDecode)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return info(codeTimeout)
+	case errors.As(err, &urlErr), errors.As(err, &netErr):
+		return info(codeNetwork)
+	}
+	return info(codeInternal)
+}
+
+func statusCode(status int) errorCode {
+	switch {
+	case status == http.StatusUnauthorized:
+		return codeAuth
+	case status == http.StatusForbidden:
+		return codeForbidden
+	case status == http.StatusNotFound:
+		return codeNotFound
+	case status == http.StatusRequestTimeout:
+		return codeTimeout
+	case status == http.StatusTooManyRequests:
+		return codeRateLimited
+	case status >= 500:
+		return codeServer
+	default:
+		return codeValidation
+	}
+}
+
+// ExitCode returns the process exit status for an error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return classOf(classifyError(err).Code).ExitCode
+}
+
+// apiFieldAnnotation names the API body fields a flag sets when they differ from
+// the flag name (e.g. --senders sets email_list).
+const apiFieldAnnotation = "instantly_api_field"
+
+// setFlagFields records the API fields a flag sets, for mapping validation errors back to it.
+func setFlagFields(cmd *cobra.Command, flag string, fields ...string) {
+	_ = cmd.Flags().SetAnnotation(flag, apiFieldAnnotation, fields)
+}
+
+// fieldDetail is an API field error plus the flag that set the field, when known.
+type fieldDetail struct {
+	api.FieldError
+	Flag string `json:"flag,omitempty"`
+}
+
+// validationDetails maps an API error's field errors back to cmd's flags.
+func validationDetails(cmd *cobra.Command, apiErr *api.APIError) []fieldDetail {
+	if len(apiErr.Details) == 0 {
+		return nil
+	}
+	out := make([]fieldDetail, len(apiErr.Details))
+	for i, fe := range apiErr.Details {
+		out[i] = fieldDetail{FieldError: fe, Flag: flagForField(cmd, fe.Field)}
+	}
+	return out
+}
+
+func (d fieldDetail) String() string {
+	s := d.Field
+	if d.Flag != "" {
+		s = d.Flag + " (" + d.Field + ")"
+	}
+	if d.Message != "" {
+		s += ": " + d.Message
+	}
+	return s
+}
+
+// flagForField finds the flag that sets an API field path: an explicit
+// annotation first, then the path, its top-level field, and an "_id" field's
+// stem, spelled as flags (daily_limit -> --daily-limit).
+func flagForField(cmd *cobra.Command, field string) string {
+	if cmd == nil || field == "" {
+		return ""
+	}
+	fs := cmd.Flags()
+	parts := strings.Split(field, ".")
+	found := ""
+	fs.VisitAll(func(f *pflag.Flag) {
+		if found == "" && slices.ContainsFunc(f.Annotations[apiFieldAnnotation], func(a string) bool {
+			return a == field || a == parts[0]
+		}) {
+			found = f.Name
+		}
+	})
+	if found != "" {
+		return "--" + found
+	}
+
+	candidates := []string{field, parts[0]}
+	if stem, ok := strings.CutSuffix(parts[0], "_id"); ok {
+		candidates = append(candidates, stem)
+	}
+	for _, c := range candidates {
+		name := strings.NewReplacer("_", "-", ".", "-").Replace(c)
+		if f := fs.Lookup(name); f != nil && !f.Hidden {
+			return "--" + name
+		}
+	}
+	return ""
+}
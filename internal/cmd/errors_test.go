@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/salmonumbrella/instantly-cli/internal/api"
+)
+
+func TestClassifyError(t *testing.T) {
+	cases := []struct {
+		err       error
+		code      errorCode
+		exit      int
+		retryable bool
+	}{
+		{errors.New("open body.json: permission denied"), codeInternal, 1, false},
+		{usageErrorf("--name is required"), codeUsage, 2, false},
+		{usageError(&api.APIError{Status: 404}), codeNotFound, 5, false},
+		{fmt.Errorf("client: %w", api.ErrMissingAPIKey), codeAuth, 3, false},
+		{&api.APIError{Status: 401}, codeAuth, 3, false},
+		{&api.APIError{Status: 403}, codeForbidden, 4, false},
+		{&api.APIError{Status: 404}, codeNotFound, 5, false},
+		{&api.APIError{Status: 422}, codeValidation, 6, false},
+		{errConfirmRequired("refusing to delete without --confirm"), codeConfirmationRequired, 7, false},
+		{&api.APIError{Status: 429}, codeRateLimited, 8, true},
+		{&api.APIError{Status: 503}, codeServer, 9, true},
+		{&api.DecodeError{Err: errors.New("bad")}, codeDecode, 13, false},
+		{fmt.Errorf("request failed: %w", &url.Error{Op: "Get", URL: "x", Err: &net.OpError{Op: "dial", Err: errors.New("refused")}}), codeNetwork, 10, true},
+		{fmt.Errorf("request failed: %w", &url.Error{Op: "Get", URL: "x", Err: context.DeadlineExceeded}), codeTimeout, 11, true},
+		{errDeadline, codeTimeout, 11, true},
+		{&api.APIError{Status: 408}, codeTimeout, 11, true},
+		{context.Canceled, codeCancelled, 130, false},
+	}
+	for _, tc := range cases {
+		info := classifyError(tc.err)
+		if info.Code != tc.code || info.Retryable != tc.retryable {
+			t.Errorf("%v: got %+v, want %s retryable=%v", tc.err, info, tc.code, tc.retryable)
+		}
+		if got := ExitCode(tc.err); got != tc.exit {
+			t.Errorf("%v: exit %d, want %d", tc.err, got, tc.exit)
+		}
+	}
+	if ExitCode(nil) != 0 {
+		t.Fatalf("nil error should exit 0")
+	}
+}
+
+func TestPrintError_CodeAndRetryAfter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"message":"slow down"}`))
+	}))
+	defer srv.Close()
+
+	res := execCLI(t, "jobs", "get", "j1", "--api-key", "k", "--base-url", srv.URL)
+	if res.Err == nil {
+		t.Fatalf("expected error")
+	}
+	got := mustJSON(t, res.Stdout).(map[string]any)
+	if got["code"] != "rate_limited" || got["retryable"] != true || got["retry_after_ms"] != float64(7000) {
+		t.Fatalf("got=%#v", got)
+	}
+}
+
+func TestConfirmRequiredCode(t *testing.T) {
+	res := execCLI(t, "campaigns", "delete", "c1", "--api-key", "k")
+	if res.Err == nil || ExitCode(res.Err) != 7 {
+		t.Fatalf("err=%v exit=%d", res.Err, ExitCode(res.Err))
+	}
+	if got := mustJSON(t, res.Stdout).(map[string]any); got["code"] != "confirmation_required" {
+		t.Fatalf("got=%#v", got)
+	}
+}
+
+func TestReportUncaught_UsageErrors(t *testing.T) {
+	root := newRootCmd()
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs([]string{"campaigns", "get", "--bogus"})
+	c, err := root.ExecuteC()
+	if err == nil || errorReported {
+		t.Fatalf("err=%v reported=%v", err, errorReported)
+	}
+	reportUncaught(c, err)
+	got := mustJSON(t, out.Bytes()).(map[string]any)
+	if got["code"] != "usage" || got["kind"] != "campaigns.get" {
+		t.Fatalf("got=%#v", got)
+	}
+	if ExitCode(err) != 2 {
+		t.Fatalf("exit=%d", ExitCode(err))
+	}
+
+	// Argument-count errors are usage errors too.
+	if res := execCLI(t, "campaigns", "get", "c1", "c2"); ExitCode(res.Err) != 2 {
+		t.Fatalf("err=%v exit=%d", res.Err, ExitCode(res.Err))
+	}
+}
+
+func TestSchemaListsErrorCodes(t *testing.T) {
+	res := execCLI(t, "schema", "--output", "json")
+	if res.Err != nil {
+		t.Fatalf("err=%v", res.Err)
+	}
+	errs := mustJSON(t, res.Stdout).(map[string]any)["errors"].([]any)
+	if len(errs) != len(errorClasses) {
+		t.Fatalf("errors=%v", errs)
+	}
+	first := errs[0].(map[string]any)
+	if first["code"] != "internal" || first["exit_code"] != float64(1) {
+		t.Fatalf("first=%v", first)
+	}
+}
+
+func TestPrintError_ValidationDetailsMapToFlags(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"body/daily_limit must be >= 1","validation":[` +
+			`{"instancePath":"/daily_limit","keyword":"minimum","message":"must be >= 1"},` +
+			`{"instancePath":"/email_list/0","keyword":"format","message":"must match format \"email\""},` +
+			`{"instancePath":"/campaign_schedule/schedules/0/timezone","keyword":"enum","message":"bad tz"}]}`))
+	}))
+	defer srv.Close()
+
+	res := execCLI(t, "campaigns", "create", "--api-key", "k", "--base-url", srv.URL,
+		"--name", "n", "--subject", "s", "--body", "b", "--senders", "nope", "--daily-limit", "0")
+	if res.Err == nil || ExitCode(res.Err) != 6 {
+		t.Fatalf("err=%v", res.Err)
+	}
+	got := mustJSON(t, res.Stdout).(map[string]any)
+	details := got["details"].([]any)
+	flagsByField := map[string]any{}
+	for _, d := range details {
+		m := d.(map[string]any)
+		flagsByField[m["field"].(string)] = m["flag"]
+	}
+	if flagsByField["daily_limit"] != "--daily-limit" || flagsByField["email_list.0"] != "--senders" || flagsByField["campaign_schedule.schedules.0.timezone"] != nil {
+		t.Fatalf("details=%#v", details)
+	}
+	if got["code"] != "validation" {
+		t.Fatalf("code=%v", got["code"])
+	}
+}
@@ -0,0 +1,360 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/salmonumbrella/instantly-cli/internal/agentfmt"
+	"github.com/salmonumbrella/instantly-cli/internal/config"
+	"github.com/salmonumbrella/instantly-cli/internal/outfmt"
+)
+
+// fanOutConcurrency caps how many profiles run at once.
+const fanOutConcurrency = 8
+
+// workspaceNameTTL is how long a looked-up workspace name is reused.
+const workspaceNameTTL = 24 * time.Hour
+
+// fanOutLocalFlags are applied once to the merged result (or decided by the
+// fan-out itself) rather than passed to each per-profile run. --max-items is
+// among them so it counts merged items that pass --where, in --sort-by order.
+var fanOutLocalFlags = []string{
+	"profiles", "all-profiles", "profile", "api-key",
+	"output", "json", "quiet", "silent",
+	"jq", "jq-arg", "jq-argjson", "jq-lib", "raw", "fields", "where", "sort-by", "max-items",
+	"columns", "no-headers", "template", "template-file", "envelope", "envelope-version", "jsonl-meta",
+}
+
+// fanOutResult is one profile's outcome, reported in meta.workspaces.
+type fanOutResult struct {
+	Profile    string       `json:"profile"`
+	Workspace  string       `json:"workspace,omitempty"`
+	OK         bool         `json:"ok"`
+	Items      *int         `json:"items,omitempty"`
+	DurationMS int64        `json:"duration_ms"`
+	Error      *fanOutError `json:"error,omitempty"`
+
+	resp any
+	// lookedUp is set when Workspace came from a lookup worth caching.
+	lookedUp bool
+}
+
+type fanOutError struct {
+	Code    errorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// fanOutProfiles returns the profiles --profiles/--all-profiles select, or nil
+// when the command runs against a single workspace.
+func fanOutProfiles(cmd *cobra.Command) ([]string, error) {
+	if len(flags.Profiles) == 0 && !flags.AllProfiles {
+		return nil, nil
+	}
+	switch {
+	case len(flags.Profiles) > 0 && flags.AllProfiles:
+		return nil, usageErrorf("--profiles and --all-profiles cannot be used together")
+	case cmd.Flags().Changed("profile"):
+		return nil, usageErrorf("--profile cannot be combined with --profiles or --all-profiles")
+	case cmd.Flags().Changed("api-key"):
+		return nil, usageErrorf("--api-key cannot be combined with --profiles or --all-profiles: each profile supplies its own key")
+	case profileMode(cmd) != "" || !cmd.Runnable():
+		return nil, usageErrorf("%s does not support --profiles or --all-profiles", cmd.CommandPath())
+	}
+
+	file, err := config.Load(flags.Config)
+	if err != nil {
+		return nil, err
+	}
+	known := file.Names()
+	if flags.AllProfiles {
+		if len(known) == 0 {
+			return nil, usageErrorf("--all-profiles: no profiles in %s", flags.Config)
+		}
+		return known, nil
+	}
+	var names []string
+	for _, name := range flags.Profiles {
+		name = strings.TrimSpace(name)
+		if name == "" || slices.Contains(names, name) {
+			continue
+		}
+		if !slices.Contains(known, name) {
+			return nil, usageErrorf("--profiles: unknown profile %q (have %s)", name, strings.Join(known, ", "))
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, usageErrorf("--profiles: no profile names given")
+	}
+	return names, nil
+}
+
+// fanOutRunE replaces the command's RunE: it re-runs the same command line
+// once per profile in a child process (the CLI keeps its settings in package
+// state, so profiles cannot share a process), then prints the merged items.
+func fanOutRunE(profiles []string) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		kind := agentfmt.KindFromCommandPath(cmd.CommandPath())
+		exe, err := os.Executable()
+		if err != nil {
+			return printError(cmd, kind, fmt.Errorf("locate executable: %w", err), nil)
+		}
+		global, local := fanOutArgs(cmd)
+		maxItems := 0
+		if f := cmd.Flags().Lookup("max-items"); f != nil && f.Changed {
+			maxItems, _ = strconv.Atoi(f.Value.String())
+			// Each profile returns every page; the cap applies after the merge.
+			local = append(local, "--all")
+		}
+		argv := slices.Concat(strings.Fields(cmd.CommandPath())[1:], args, global, local)
+		names := config.WorkspaceNamesFor(flags.Config)
+		cached := names.Load()
+
+		results := make([]*fanOutResult, len(profiles))
+		sem := make(chan struct{}, fanOutConcurrency)
+		var wg sync.WaitGroup
+		var stderrMu sync.Mutex
+		for i, profile := range profiles {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				known := ""
+				if c, ok := cached[profile]; ok && time.Since(c.FetchedAt) < workspaceNameTTL {
+					known = c.Name
+				}
+				r, stderr := runProfile(cmd.Context(), exe, profile, known, perProfileArgs(argv, profile), perProfileArgs(global, profile))
+				results[i] = r
+				if len(stderr) > 0 {
+					stderrMu.Lock()
+					writePrefixed(cmd, profile, stderr)
+					stderrMu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		items := []any{}
+		var failed []*fanOutResult
+		lookedUp := false
+		for _, r := range results {
+			if r.lookedUp {
+				cached[r.Profile] = config.WorkspaceName{Name: r.Workspace, FetchedAt: time.Now()}
+				lookedUp = true
+			}
+			if !r.OK {
+				failed = append(failed, r)
+				continue
+			}
+			tagged := tagItems(r)
+			n := len(tagged)
+			r.Items = &n
+			items = append(items, tagged...)
+		}
+		if lookedUp {
+			_ = names.Save(cached)
+		}
+		meta := map[string]any{"workspaces": results, "failed_workspaces": len(failed)}
+		if len(failed) > 0 && !metaShown(outfmt.ModeFrom(cmd.Context())) {
+			// meta.workspaces is not printed, so name the failures here.
+			for _, r := range failed {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "[%s] %s: %s\n", r.Profile, r.Error.Code, r.Error.Message)
+			}
+		}
+		if len(failed) == len(results) {
+			first := failed[0].Error
+			return printError(cmd, kind, &codedError{
+				code: first.Code,
+				err:  fmt.Errorf("all %d profiles failed; %s: %s", len(results), failed[0].Profile, first.Message),
+			}, meta)
+		}
+		var merged any = map[string]any{"items": items}
+		if maxItems > 0 {
+			// printResult runs --where/--sort-by again; on a filtered list that is a no-op.
+			if merged, err = applyListFilter(merged); err != nil {
+				return printError(cmd, kind, err, meta)
+			}
+			merged, meta["pagination"] = capItems(merged, maxItems)
+		}
+		if err := printResult(cmd, kind, merged, meta); err != nil || len(failed) == 0 {
+			return err
+		}
+		// The merged output is already printed; still exit non-zero.
+		errorReported = true
+		return &codedError{code: codePartialFailure, err: fmt.Errorf("%d of %d profiles failed", len(failed), len(results))}
+	}
+}
+
+// capItems keeps the first limit items of a merged list.
+func capItems(merged any, limit int) (any, map[string]any) {
+	m, _ := merged.(map[string]any)
+	items, _ := m["items"].([]any)
+	truncated := len(items) > limit
+	if truncated {
+		items = items[:limit]
+	}
+	return map[string]any{"items": items}, map[string]any{"items": len(items), "truncated": truncated}
+}
+
+// metaShown reports whether the output format prints meta.
+func metaShown(mode outfmt.Mode) bool {
+	switch mode {
+	case outfmt.Agent:
+		return true
+	case outfmt.YAML, outfmt.Template:
+		return flags.Envelope
+	case outfmt.JSONL:
+		return flags.JSONLMeta
+	}
+	return false
+}
+
+// perProfileArgs points --record/--replay at a subdirectory named after the
+// profile, so concurrent runs neither overwrite nor replay each other's cassettes.
+func perProfileArgs(args []string, profile string) []string {
+	out := slices.Clone(args)
+	for i, arg := range out {
+		for _, prefix := range []string{"--record=", "--replay="} {
+			if dir, ok := strings.CutPrefix(arg, prefix); ok {
+				out[i] = prefix + filepath.Join(dir, profile)
+			}
+		}
+	}
+	return out
+}
+
+// fanOutArgs re-serializes the flags set on the command line, split into root
+// persistent flags and the command's own, minus fanOutLocalFlags.
+func fanOutArgs(cmd *cobra.Command) (global, local []string) {
+	persistent := cmd.Root().PersistentFlags()
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if slices.Contains(fanOutLocalFlags, f.Name) {
+			return
+		}
+		var vals []string
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			vals = sv.GetSlice()
+		} else {
+			vals = []string{f.Value.String()}
+		}
+		for _, v := range vals {
+			arg := "--" + f.Name + "=" + v
+			if persistent.Lookup(f.Name) != nil {
+				global = append(global, arg)
+			} else {
+				local = append(local, arg)
+			}
+		}
+	})
+	return global, local
+}
+
+// runProfile runs argv for one profile and, when neither a saved login nor
+// known (the cached name from an earlier run) names the workspace, looks it up.
+func runProfile(ctx context.Context, exe, profile, known string, argv, global []string) (*fanOutResult, []byte) {
+	start := time.Now()
+	r := &fanOutResult{Profile: profile}
+	resp, stderr, err := runChild(ctx, exe, profile, argv)
+	r.DurationMS = time.Since(start).Milliseconds()
+	if err != nil {
+		r.Error = err
+		return r, stderr
+	}
+	r.OK, r.resp = true, resp
+
+	if cred, ok, _ := credentialStore().Get(profile); ok && cred.WorkspaceName != "" {
+		r.Workspace = cred.WorkspaceName
+	} else if known != "" {
+		r.Workspace = known
+	} else if !flags.DryRun && flags.Replay == "" {
+		// The lookup is not part of the command, so keep it out of its cassette.
+		lookup := slices.DeleteFunc(slices.Clone(global), func(arg string) bool { return strings.HasPrefix(arg, "--record=") })
+		ws, _, err := runChild(ctx, exe, profile, slices.Concat([]string{"workspaces", "current", "get"}, lookup))
+		if m, ok := ws.(map[string]any); ok && err == nil {
+			r.Workspace = stringField(m, "name")
+			r.lookedUp = r.Workspace != ""
+		}
+	}
+	return r, stderr
+}
+
+// runChild runs the CLI for profile with --output json and decodes its stdout,
+// which holds the response on success and the error payload on failure.
+func runChild(ctx context.Context, exe, profile string, argv []string) (any, []byte, *fanOutError) {
+	c := exec.CommandContext(ctx, exe, slices.Concat(argv, []string{"--profile=" + profile, "--output=json"})...)
+	// The profile, not the parent's environment, supplies the key.
+	c.Env = slices.DeleteFunc(os.Environ(), func(kv string) bool {
+		return strings.HasPrefix(kv, "INSTANTLY_API_KEY=") || strings.HasPrefix(kv, "INSTANTLY_PROFILE=")
+	})
+	var stdout, stderr bytes.Buffer
+	c.Stdout, c.Stderr = &stdout, &stderr
+	runErr := c.Run()
+
+	var resp any
+	if b := bytes.TrimSpace(stdout.Bytes()); len(b) > 0 {
+		if err := jsonUnmarshal(b, &resp); err != nil {
+			return nil, stderr.Bytes(), &fanOutError{Code: codeDecode, Message: "unreadable output: " + err.Error()}
+		}
+	}
+	if runErr == nil {
+		return resp, stderr.Bytes(), nil
+	}
+	if ctx.Err() != nil {
+		return nil, stderr.Bytes(), &fanOutError{Code: codeCancelled, Message: context.Cause(ctx).Error()}
+	}
+	out := &fanOutError{Code: codeInternal, Message: runErr.Error()}
+	if m, ok := resp.(map[string]any); ok {
+		if code, _ := m["code"].(string); code != "" {
+			out.Code = errorCode(code)
+		}
+		if msg, _ := m["error"].(string); msg != "" {
+			out.Message = msg
+		}
+	} else if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		out.Message = msg
+	}
+	return nil, stderr.Bytes(), out
+}
+
+// tagItems returns a profile's list items, or its single response, each
+// tagged with _profile and _workspace.
+func tagItems(r *fanOutResult) []any {
+	var raw []any
+	if items, ok := agentfmt.ListItems(r.resp); ok {
+		raw, _ = items.([]any)
+	} else if r.resp != nil {
+		raw = []any{r.resp}
+	}
+	out := make([]any, 0, len(raw))
+	for _, it := range raw {
+		m, ok := it.(map[string]any)
+		if !ok {
+			m = map[string]any{"value": it}
+		}
+		m["_profile"] = r.Profile
+		m["_workspace"] = r.Workspace
+		out = append(out, m)
+	}
+	return out
+}
+
+func writePrefixed(cmd *cobra.Command, profile string, b []byte) {
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "[%s] %s\n", profile, sc.Text())
+	}
+}
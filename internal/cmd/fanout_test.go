@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/salmonumbrella/instantly-cli/internal/config"
+)
+
+func fanOutServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if key == "key-bad" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Invalid API key"}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/workspaces/current":
+			_, _ = w.Write([]byte(`{"id":"w-` + key + `","name":"Workspace ` + key + `"}`))
+		case "/accounts":
+			_, _ = w.Write([]byte(`{"items":[{"email":"a@` + key + `.com"},{"email":"b@` + key + `.com"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"count":` + map[string]string{"key-a": "3", "key-b": "5"}[key] + `}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFanOut_MergesAndTagsPerProfile(t *testing.T) {
+	srv := fanOutServer(t)
+	writeConfig(t, "profiles:\n"+
+		"  a:\n    api_key: key-a\n    base_url: "+srv.URL+"\n"+
+		"  b:\n    api_key: key-b\n    base_url: "+srv.URL+"\n"+
+		"  c:\n    api_key: key-bad\n    base_url: "+srv.URL+"\n")
+	t.Setenv("INSTANTLY_API_KEY", "key-env")
+	t.Setenv("INSTANTLY_TEST_RUN_CLI", "1")
+
+	// One profile failing still prints the others, then exits partial_failure.
+	res := execCLI(t, "--all-profiles", "--sort-by", "email:desc", "accounts", "list")
+	if ExitCode(res.Err) != 14 {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+	env := mustJSON(t, res.Stdout).(map[string]any)
+	items := env["items"].([]any)
+	if len(items) != 4 {
+		t.Fatalf("items=%v", items)
+	}
+	first := items[0].(map[string]any)
+	if first["email"] != "b@key-b.com" || first["_profile"] != "b" || first["_workspace"] != "Workspace key-b" {
+		t.Fatalf("first=%v", first)
+	}
+	meta := env["meta"].(map[string]any)
+	ws := meta["workspaces"].([]any)
+	if len(ws) != 3 || meta["failed_workspaces"] != float64(1) {
+		t.Fatalf("meta=%v", meta)
+	}
+	failed := ws[2].(map[string]any)
+	if failed["profile"] != "c" || failed["ok"] != false || failed["error"].(map[string]any)["code"] != "auth" {
+		t.Fatalf("failed=%v", failed)
+	}
+	if w := env["warnings"].([]any); len(w) != 1 || !strings.Contains(w[0].(string), "c (auth)") {
+		t.Fatalf("warnings=%v", w)
+	}
+
+	// A single response per profile becomes one tagged item each.
+	res = execCLI(t, "--json", "--profiles", "a,b", "emails", "unread-count")
+	if res.Err != nil {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+	got := mustJSON(t, res.Stdout).(map[string]any)["items"].([]any)
+	if len(got) != 2 || got[0].(map[string]any)["count"] != float64(3) || got[1].(map[string]any)["_profile"] != "b" {
+		t.Fatalf("items=%v", got)
+	}
+
+	// Without an envelope, each failed profile gets a line on stderr.
+	res = execCLI(t, "--json", "--all-profiles", "accounts", "list")
+	if ExitCode(res.Err) != 14 || !strings.Contains(string(res.Stderr), "[c] auth: ") {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+
+	// Every profile failing is an error with the first failure's code.
+	res = execCLI(t, "--json", "--profiles", "c", "accounts", "list")
+	if ExitCode(res.Err) != 3 {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+}
+
+func TestFanOut_MaxItemsAfterWhere(t *testing.T) {
+	srv := fanOutServer(t)
+	writeConfig(t, "profiles:\n"+
+		"  a:\n    api_key: key-a\n    base_url: "+srv.URL+"\n"+
+		"  b:\n    api_key: key-b\n    base_url: "+srv.URL+"\n")
+	t.Setenv("INSTANTLY_TEST_RUN_CLI", "1")
+
+	// Each profile's first item fails --where, so capping per profile would find nothing.
+	res := execCLI(t, "--all-profiles", "--where", "email~^b", "--sort-by", "email:desc", "--max-items", "1", "accounts", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+	env := mustJSON(t, res.Stdout).(map[string]any)
+	items := env["items"].([]any)
+	if len(items) != 1 || items[0].(map[string]any)["email"] != "b@key-b.com" {
+		t.Fatalf("items=%v", items)
+	}
+	if p := env["meta"].(map[string]any)["pagination"].(map[string]any); p["truncated"] != true {
+		t.Fatalf("pagination=%v", p)
+	}
+}
+
+func TestFanOut_CachesWorkspaceNames(t *testing.T) {
+	srv := fanOutServer(t)
+	path := writeConfig(t, "profiles:\n  a:\n    api_key: key-a\n    base_url: "+srv.URL+"\n")
+	t.Setenv("INSTANTLY_TEST_RUN_CLI", "1")
+
+	if res := execCLI(t, "--json", "--all-profiles", "accounts", "list"); res.Err != nil {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+	names := config.WorkspaceNamesFor(path)
+	cached := names.Load()
+	if cached["a"].Name != "Workspace key-a" {
+		t.Fatalf("cached=%v", cached)
+	}
+
+	// A fresh cached name is used without asking the API again.
+	cached["a"] = config.WorkspaceName{Name: "Cached", FetchedAt: time.Now()}
+	if err := names.Save(cached); err != nil {
+		t.Fatal(err)
+	}
+	res := execCLI(t, "--json", "--all-profiles", "accounts", "list")
+	if got := mustJSON(t, res.Stdout).(map[string]any)["items"].([]any); got[0].(map[string]any)["_workspace"] != "Cached" {
+		t.Fatalf("items=%v", got)
+	}
+}
+
+func TestFanOut_RecordsPerProfile(t *testing.T) {
+	srv := fanOutServer(t)
+	writeConfig(t, "profiles:\n"+
+		"  a:\n    api_key: key-a\n    base_url: "+srv.URL+"\n"+
+		"  b:\n    api_key: key-b\n    base_url: "+srv.URL+"\n")
+	t.Setenv("INSTANTLY_TEST_RUN_CLI", "1")
+	dir := t.TempDir()
+
+	res := execCLI(t, "--json", "--all-profiles", "--record", dir, "accounts", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+	for _, profile := range []string{"a", "b"} {
+		files, _ := filepath.Glob(filepath.Join(dir, profile, "*.json"))
+		if len(files) != 1 || filepath.Base(files[0]) != "0001-get-accounts.json" {
+			t.Fatalf("%s: files=%v", profile, files)
+		}
+	}
+
+	srv.Close()
+	res = execCLI(t, "--json", "--all-profiles", "--replay", dir, "accounts", "list")
+	if res.Err != nil {
+		t.Fatalf("replay err=%v stdout=%s", res.Err, res.Stdout)
+	}
+	if got := mustJSON(t, res.Stdout).(map[string]any)["items"].([]any); len(got) != 4 {
+		t.Fatalf("items=%v", got)
+	}
+}
+
+func TestFanOut_Validation(t *testing.T) {
+	writeConfig(t, "profiles:\n  a:\n    api_key: k\n")
+	for _, args := range [][]string{
+		{"--profiles", "a", "--all-profiles", "accounts", "list"},
+		{"--profiles", "nope", "accounts", "list"},
+		{"--profiles", "a", "--profile", "a", "accounts", "list"},
+		{"--profiles", "a", "--api-key", "k", "accounts", "list"},
+		{"--profiles", "a", "config", "list"},
+	} {
+		res := execCLI(t, append([]string{"--json"}, args...)...)
+		if ExitCode(res.Err) != 2 {
+			t.Fatalf("%v: err=%v", args, res.Err)
+		}
+	}
+}
@@ -3,14 +3,13 @@ package cmd
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 )
 
-var fieldNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*$`)
-
 func effectiveJQExpression() (string, error) {
 	if strings.TrimSpace(flags.JQ) != "" && strings.TrimSpace(flags.Fields) != "" {
-		return "", fmt.Errorf("--jq and --fields cannot be used together")
+		return "", usageErrorf("--jq and --fields cannot be used together")
 	}
 	if strings.TrimSpace(flags.JQ) != "" {
 		return flags.JQ, nil
@@ -25,74 +24,177 @@ func effectiveJQExpression() (string, error) {
 	return "", nil
 }
 
-func parseFields(input string) ([]string, error) {
+// fieldSpec is one --fields entry: a path such as "campaign.id",
+// "sequences[].steps[].delay" or "payload_used.*", optionally renamed with
+// ":alias" or excluded with a leading "-".
+type fieldSpec struct {
+	Path    string
+	Alias   string
+	Exclude bool
+	// segs holds one key per path segment; "[]" marks iteration over an array
+	// (or, for "*", over an object's values).
+	segs []string
+}
+
+var (
+	fieldSegRe   = regexp.MustCompile(`^(?:\*|[a-zA-Z_][a-zA-Z0-9_]*(?:\[\])*)$`)
+	fieldAliasRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+func parseFields(input string) ([]fieldSpec, error) {
 	input = strings.TrimSpace(input)
 	if input == "" {
-		return nil, fmt.Errorf("empty --fields")
+		return nil, usageErrorf("empty --fields")
 	}
 
 	raw := strings.Split(input, ",")
-	out := make([]string, 0, len(raw))
+	out := make([]fieldSpec, 0, len(raw))
 	seen := map[string]bool{}
 	for _, f := range raw {
 		f = strings.TrimSpace(f)
-		if f == "" {
+		if f == "" || seen[f] {
 			continue
 		}
-		if !fieldNameRe.MatchString(f) {
-			return nil, fmt.Errorf("invalid field %q (expected: a, a_b, a.b, a.b_c)", f)
-		}
-		if !seen[f] {
-			out = append(out, f)
-			seen[f] = true
+		spec, err := parseFieldSpec(f)
+		if err != nil {
+			return nil, err
 		}
+		out = append(out, spec)
+		seen[f] = true
 	}
 	if len(out) == 0 {
-		return nil, fmt.Errorf("no valid fields in --fields")
+		return nil, usageErrorf("no valid fields in --fields")
 	}
 	return out, nil
 }
 
-func buildFieldsQuery(fields []string) string {
+func parseFieldSpec(f string) (fieldSpec, error) {
+	invalid := usageErrorf("invalid field %q (expected: a, a.b, a[].b, a.*, a.b:alias, -a)", f)
+	var spec fieldSpec
+	path := f
+	if rest, ok := strings.CutPrefix(path, "-"); ok {
+		spec.Exclude = true
+		path = rest
+	}
+	if p, alias, ok := strings.Cut(path, ":"); ok {
+		if spec.Exclude || !fieldAliasRe.MatchString(alias) {
+			return fieldSpec{}, invalid
+		}
+		path, spec.Alias = p, alias
+	}
+	for i, seg := range strings.Split(path, ".") {
+		if !fieldSegRe.MatchString(seg) || (i == 0 && seg == "*") {
+			return fieldSpec{}, invalid
+		}
+		key, _, _ := strings.Cut(seg, "[")
+		if seg == "*" {
+			spec.segs = append(spec.segs, "[]")
+			continue
+		}
+		spec.segs = append(spec.segs, key)
+		for range strings.Count(seg, "[]") {
+			spec.segs = append(spec.segs, "[]")
+		}
+	}
+	spec.Path = path
+	return spec, nil
+}
+
+// iterates reports whether the path fans out over arrays or wildcards.
+func (s fieldSpec) iterates() bool {
+	return slices.Contains(s.segs, "[]")
+}
+
+// jqPath renders the spec as a jq path expression; "?" keeps records where an
+// intermediate value has the wrong type from failing the whole projection.
+func (s fieldSpec) jqPath() string {
+	var b strings.Builder
+	for _, seg := range s.segs {
+		if seg == "[]" {
+			b.WriteString("[]?")
+			continue
+		}
+		b.WriteString("." + seg + "?")
+	}
+	return b.String()
+}
+
+func buildFieldsQuery(fields []fieldSpec) string {
 	// Works for both:
-	// - agent output envelope: {kind, items|item|data, meta}
-	// - raw API output: {items, next_starting_after} etc.
+	// - agent output envelope: {kind, items|item|data|result, meta, ...}
+	// - raw API output: {items, next_starting_after}, bare arrays or objects.
 	//
-	// Agent envelopes keep their envelope keys; raw outputs are projected directly.
-	objectExpr := buildObjectExpr(fields)
+	// Either way the projection applies to each record; agent envelopes keep
+	// their other keys, raw lists become an array of projected records.
 	return fmt.Sprintf(`
-if (type == "object") then
-  if (has("kind")) then
-    if (has("items") and (.items|type=="array")) then
-      {kind: .kind, meta: .meta, items: [.items[] | %s]}
-    elif (has("item") and (.item|type=="object")) then
-      {kind: .kind, meta: .meta, item: (.item | %s)}
-    elif (has("data") and (.data|type=="object")) then
-      {kind: .kind, meta: .meta, data: (.data | %s)}
-    else
-      %s
-    end
-  else
-    if (has("items") and (.items|type=="array")) then
-      [.items[] | %s]
-    else
-      %s
-    end
+def _proj: %s;
+def _each: if type == "array" then map(if type == "object" then _proj else . end) elif type == "object" then _proj else . end;
+if (type == "object") and has("kind") then
+  if has("items") then .items |= _each
+  elif has("item") then .item |= _each
+  elif has("result") then .result |= _each
+  elif has("data") then .data |= _each
+  else _proj
   end
+elif (type == "object") and ((.items | type) == "array") then
+  .items | _each
 else
-  .
+  _each
 end
-`, objectExpr, objectExpr, objectExpr, objectExpr, objectExpr, objectExpr)
+`, buildProjection(fields))
 }
 
-func buildObjectExpr(fields []string) string {
-	parts := make([]string, 0, len(fields))
+// buildProjection builds a jq filter that keeps the included paths of an object
+// with their nesting, adds aliased values as top-level keys, and then drops
+// excluded paths. Exclusions alone keep everything else.
+func buildProjection(fields []fieldSpec) string {
+	var include, alias, exclude []string
 	for _, f := range fields {
-		key := f
-		if i := strings.LastIndex(f, "."); i >= 0 {
-			key = f[i+1:]
+		switch {
+		case f.Exclude:
+			exclude = append(exclude, "path("+f.jqPath()+")")
+		case f.Alias != "":
+			value := "[$in | " + f.jqPath() + "]"
+			if !f.iterates() {
+				value += "[0]"
+			}
+			alias = append(alias, f.Alias+": "+value)
+		default:
+			include = append(include, "path("+f.jqPath()+")")
+		}
+	}
+
+	expr := ". as $in | "
+	switch {
+	case len(include) > 0:
+		expr += "(reduce (" + strings.Join(include, ", ") + ") as $p ({}; setpath($p; $in | getpath($p))))"
+	case len(alias) > 0:
+		expr += "{}"
+	default:
+		expr += "$in"
+	}
+	if len(alias) > 0 {
+		expr += " + {" + strings.Join(alias, ", ") + "}"
+	}
+	if len(exclude) > 0 {
+		expr += " | delpaths([" + strings.Join(exclude, ", ") + "])"
+	}
+	return expr
+}
+
+// fieldColumns returns csv/tsv columns for plain and aliased paths, in order,
+// or nil when a spec iterates or excludes and the columns come from the data.
+func fieldColumns(fields []fieldSpec) []string {
+	cols := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f.Exclude || (f.iterates() && f.Alias == "") {
+			return nil
+		}
+		col := f.Path
+		if f.Alias != "" {
+			col = f.Alias
 		}
-		parts = append(parts, fmt.Sprintf("%s: .%s", key, f))
+		cols = append(cols, col)
 	}
-	return "{ " + strings.Join(parts, ", ") + " }"
+	return cols
 }
@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/salmonumbrella/instantly-cli/internal/filter"
 )
 
 func TestDefaultOutput(t *testing.T) {
@@ -63,8 +68,10 @@ func TestParseFields(t *testing.T) {
 	if _, err := parseFields(""); err == nil {
 		t.Fatalf("expected error")
 	}
-	if _, err := parseFields("a,-b"); err == nil {
-		t.Fatalf("expected error")
+	for _, bad := range []string{"a.-b", "-a:b", "a:1x", "*", "a[0]", "a..b"} {
+		if _, err := parseFields(bad); err == nil {
+			t.Fatalf("%q: expected error", bad)
+		}
 	}
 	if _, err := parseFields(", ,"); err == nil {
 		t.Fatalf("expected error")
@@ -82,9 +89,77 @@ func TestParseFields(t *testing.T) {
 	}
 }
 
-func TestBuildObjectExpr(t *testing.T) {
-	got := buildObjectExpr([]string{"a", "b.c"})
-	if !strings.Contains(got, "a: .a") || !strings.Contains(got, "c: .b.c") {
-		t.Fatalf("got=%q", got)
+func TestFieldsProjection(t *testing.T) {
+	record := map[string]any{
+		"id":       "l1",
+		"campaign": map[string]any{"id": "c1", "name": "Q3"},
+		"lead":     map[string]any{"id": "x1"},
+		"sequences": []any{
+			map[string]any{"steps": []any{map[string]any{"delay": 1, "type": "email"}, map[string]any{"delay": 2}}},
+		},
+		"payload_used": map[string]any{"city": "Oslo", "title": "CTO"},
+		"meta":         map[string]any{"x": 1},
+	}
+	cases := []struct {
+		fields string
+		want   string
+	}{
+		{"campaign.id,lead.id", `{"campaign":{"id":"c1"},"lead":{"id":"x1"}}`},
+		{"campaign.id:campaign_id,lead.id:lead_id", `{"campaign_id":"c1","lead_id":"x1"}`},
+		{"sequences[].steps[].delay", `{"sequences":[{"steps":[{"delay":1},{"delay":2}]}]}`},
+		{"sequences[].steps[].delay:delays", `{"delays":[1,2]}`},
+		{"payload_used.*", `{"payload_used":{"city":"Oslo","title":"CTO"}}`},
+		{"-meta,-sequences,-payload_used,-lead", `{"campaign":{"id":"c1","name":"Q3"},"id":"l1"}`},
+		{"id,campaign,-campaign.name", `{"campaign":{"id":"c1"},"id":"l1"}`},
+		{"missing.path", `{"missing":{"path":null}}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.fields, func(t *testing.T) {
+			fields, err := parseFields(tc.fields)
+			if err != nil {
+				t.Fatalf("err=%v", err)
+			}
+			// Raw object, raw list and agent envelope all project the same record.
+			inputs := []any{
+				record,
+				map[string]any{"items": []any{record}},
+				map[string]any{"kind": "k", "type": "item", "item": record},
+			}
+			for i, in := range inputs {
+				got, err := filter.Apply(in, buildFieldsQuery(fields))
+				if err != nil {
+					t.Fatalf("input %d: err=%v", i, err)
+				}
+				switch i {
+				case 1:
+					got = got.([]any)[0]
+				case 2:
+					got = got.(map[string]any)["item"]
+				}
+				if b, _ := json.Marshal(got); string(b) != tc.want {
+					t.Fatalf("input %d: got %s, want %s", i, b, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestOutput_PreservesLargeNumbers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":[{"id":9007199254740993,"sent":1000000},{"id":9007199254740995,"sent":5}]}`))
+	}))
+	defer srv.Close()
+
+	res := execCLI(t, "api", "get", "/x", "--api-key", "k", "--base-url", srv.URL, "--output", "json", "--jq", "[.items[] | select(.sent > 100) | .id]")
+	if res.Err != nil {
+		t.Fatalf("err=%v", res.Err)
+	}
+	if got := strings.Join(strings.Fields(string(res.Stdout)), ""); got != "[9007199254740993]" {
+		t.Fatalf("stdout=%q", res.Stdout)
+	}
+
+	res = execCLI(t, "api", "get", "/x", "--api-key", "k", "--base-url", srv.URL, "--output", "jsonl")
+	if !strings.Contains(string(res.Stdout), `"sent":1000000`) || !strings.Contains(string(res.Stdout), "9007199254740995") {
+		t.Fatalf("stdout=%q", res.Stdout)
 	}
 }
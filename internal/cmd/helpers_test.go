@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -107,13 +110,14 @@ func TestReadJSONObjectInputAndMergeMaps(t *testing.T) {
 	dst := map[string]any{"b": map[string]any{"d": 3}}
 	mergeMaps(dst, m)
 	bm := dst["b"].(map[string]any)
-	// json.Unmarshal numeric types can vary; accept int/float64.
+	// Decoded numbers are json.Number; literals in the test are int.
 	asInt := func(v any) int {
 		switch vv := v.(type) {
 		case int:
 			return vv
-		case float64:
-			return int(vv)
+		case json.Number:
+			n, _ := vv.Int64()
+			return int(n)
 		default:
 			return 0
 		}
@@ -122,3 +126,16 @@ func TestReadJSONObjectInputAndMergeMaps(t *testing.T) {
 		t.Fatalf("dst=%#v", dst)
 	}
 }
+
+// jsonServer serves body as JSON for every request. COLUMNS is cleared so
+// text output is not wrapped to the terminal running the tests.
+func jsonServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("COLUMNS", "")
+	return srv
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestAutoIdempotency_ReusesKeyAndRetriesWrites(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		keys []string
+		fail = 3
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if fail > 0 {
+			fail--
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"l1"}`))
+	}))
+	defer srv.Close()
+
+	journal := filepath.Join(t.TempDir(), "journal.json")
+	run := func(email string, wantErr bool) map[string]any {
+		t.Helper()
+		res := execCLI(t, "leads", "create", "--email", email,
+			"--api-key", "k", "--base-url", srv.URL, "--retry-delay", "1ms", "--max-5xx-retries", "1",
+			"--auto-idempotency", "--idempotency-journal", journal)
+		if (res.Err != nil) != wantErr {
+			t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+		}
+		env := mustJSON(t, res.Stdout).(map[string]any)
+		return env["meta"].(map[string]any)["idempotency"].(map[string]any)
+	}
+
+	// The 502 is retried because the write now carries a key, but both attempts fail.
+	first := run("a@example.com", true)
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] || first["reused"] != false {
+		t.Fatalf("keys=%v first=%v", keys, first)
+	}
+
+	// Re-running the identical command after the failure reuses the journaled key.
+	again := run("a@example.com", false)
+	if again["key"] != first["key"] || again["reused"] != true || again["previous_status"] != float64(502) || keys[2] != keys[0] {
+		t.Fatalf("again=%v keys=%v", again, keys)
+	}
+
+	// Once it succeeded, running it again is a deliberate new write with a fresh key.
+	fresh := run("a@example.com", false)
+	if fresh["key"] == first["key"] || fresh["reused"] != false {
+		t.Fatalf("fresh=%v", fresh)
+	}
+
+	// A different payload is a different logical write.
+	other := run("b@example.com", false)
+	if other["key"] == first["key"] || other["reused"] != false {
+		t.Fatalf("other=%v", other)
+	}
+}
@@ -41,13 +41,14 @@ func newInboxPlacementTestsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List inbox placement tests (GET /inbox-placement-tests)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.tests.list", err, nil)
 			}
@@ -61,15 +62,12 @@ func newInboxPlacementTestsListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "inbox_placement.tests.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/inbox-placement-tests", q)
-			if err != nil {
-				return printError(cmd, "inbox_placement.tests.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "inbox_placement.tests.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "inbox_placement.tests.list", "/inbox-placement-tests", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -80,13 +78,13 @@ func newInboxPlacementTestsGetCmd() *cobra.Command {
 		Short: "Get inbox placement test (GET /inbox-placement-tests/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.tests.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "inbox_placement.tests.get", fmt.Errorf("test_id is required"), nil)
+				return printError(cmd, "inbox_placement.tests.get", usageErrorf("test_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/inbox-placement-tests/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -109,9 +107,9 @@ func newInboxPlacementTestsCreateCmd() *cobra.Command {
 		Short: "Create inbox placement test (POST /inbox-placement-tests, requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "inbox_placement.tests.create", fmt.Errorf("refusing to create test without --confirm"), nil)
+				return printError(cmd, "inbox_placement.tests.create", errConfirmRequired("refusing to create test without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.tests.create", err, nil)
 			}
@@ -120,7 +118,7 @@ func newInboxPlacementTestsCreateCmd() *cobra.Command {
 				return printError(cmd, "inbox_placement.tests.create", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "inbox_placement.tests.create", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "inbox_placement.tests.create", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/inbox-placement-tests", nil, body)
 			if err != nil {
@@ -145,20 +143,20 @@ func newInboxPlacementTestsUpdateCmd() *cobra.Command {
 		Short: "Update inbox placement test (PATCH /inbox-placement-tests/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.tests.update", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "inbox_placement.tests.update", fmt.Errorf("test_id is required"), nil)
+				return printError(cmd, "inbox_placement.tests.update", usageErrorf("test_id is required"), nil)
 			}
 			body, err := readJSONObjectInput(dataJSON, dataFile)
 			if err != nil {
 				return printError(cmd, "inbox_placement.tests.update", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "inbox_placement.tests.update", fmt.Errorf("provide --data-json or --data-file with at least one field"), nil)
+				return printError(cmd, "inbox_placement.tests.update", usageErrorf("provide --data-json or --data-file with at least one field"), nil)
 			}
 			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/inbox-placement-tests/"+url.PathEscape(id), nil, body)
 			if err != nil {
@@ -180,15 +178,15 @@ func newInboxPlacementTestsDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "inbox_placement.tests.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "inbox_placement.tests.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.tests.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "inbox_placement.tests.delete", fmt.Errorf("test_id is required"), nil)
+				return printError(cmd, "inbox_placement.tests.delete", usageErrorf("test_id is required"), nil)
 			}
 			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/inbox-placement-tests/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -207,7 +205,7 @@ func newInboxPlacementTestsESPsCmd() *cobra.Command {
 		Aliases: []string{"email-service-provider-options", "esp-options"},
 		Short:   "List email service provider options (GET /inbox-placement-tests/email-service-provider-options)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.tests.esps", err, nil)
 			}
@@ -240,13 +238,14 @@ func newInboxPlacementAnalyticsListCmd() *cobra.Command {
 		testID        string
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List inbox placement analytics (GET /inbox-placement-analytics; requires test_id)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.analytics.list", err, nil)
 			}
@@ -264,18 +263,15 @@ func newInboxPlacementAnalyticsListCmd() *cobra.Command {
 				return printError(cmd, "inbox_placement.analytics.list", err, nil)
 			}
 			if strings.TrimSpace(q.Get("test_id")) == "" {
-				return printError(cmd, "inbox_placement.analytics.list", fmt.Errorf("missing required test_id (set --test-id or pass --query test_id=...)"), nil)
-			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/inbox-placement-analytics", q)
-			if err != nil {
-				return printError(cmd, "inbox_placement.analytics.list", err, metaFrom(meta, nil))
+				return printError(cmd, "inbox_placement.analytics.list", usageErrorf("missing required test_id (set --test-id or pass --query test_id=...)"), nil)
 			}
-			return printResult(cmd, "inbox_placement.analytics.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "inbox_placement.analytics.list", "/inbox-placement-analytics", q, pages)
 		},
 	}
 	cmd.Flags().StringVar(&testID, "test-id", "", "Inbox placement test ID (required unless provided via --query test_id=...)")
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -286,13 +282,13 @@ func newInboxPlacementAnalyticsGetCmd() *cobra.Command {
 		Short: "Get inbox placement analytics record (GET /inbox-placement-analytics/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.analytics.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "inbox_placement.analytics.get", fmt.Errorf("analytics_id is required"), nil)
+				return printError(cmd, "inbox_placement.analytics.get", usageErrorf("analytics_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/inbox-placement-analytics/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -314,7 +310,7 @@ func newInboxPlacementAnalyticsStatsByTestIDCmd() *cobra.Command {
 		Use:   "stats-by-test-id",
 		Short: "Stats by test ID (POST /inbox-placement-analytics/stats-by-test-id)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.analytics.stats_by_test_id", err, nil)
 			}
@@ -331,7 +327,7 @@ func newInboxPlacementAnalyticsStatsByTestIDCmd() *cobra.Command {
 				body["test_id"] = strings.TrimSpace(testID)
 			}
 			if strings.TrimSpace(fmt.Sprint(body["test_id"])) == "" || body["test_id"] == nil {
-				return printError(cmd, "inbox_placement.analytics.stats_by_test_id", fmt.Errorf("missing required test_id (set --test-id or set test_id in --data-json/--data-file)"), nil)
+				return printError(cmd, "inbox_placement.analytics.stats_by_test_id", usageErrorf("missing required test_id (set --test-id or set test_id in --data-json/--data-file)"), nil)
 			}
 
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/inbox-placement-analytics/stats-by-test-id", nil, body)
@@ -357,7 +353,7 @@ func newInboxPlacementAnalyticsDeliverabilityInsightsCmd() *cobra.Command {
 		Use:   "deliverability-insights",
 		Short: "Deliverability insights (POST /inbox-placement-analytics/deliverability-insights)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.analytics.deliverability_insights", err, nil)
 			}
@@ -374,7 +370,7 @@ func newInboxPlacementAnalyticsDeliverabilityInsightsCmd() *cobra.Command {
 				body["test_id"] = strings.TrimSpace(testID)
 			}
 			if strings.TrimSpace(fmt.Sprint(body["test_id"])) == "" || body["test_id"] == nil {
-				return printError(cmd, "inbox_placement.analytics.deliverability_insights", fmt.Errorf("missing required test_id (set --test-id or set test_id in --data-json/--data-file)"), nil)
+				return printError(cmd, "inbox_placement.analytics.deliverability_insights", usageErrorf("missing required test_id (set --test-id or set test_id in --data-json/--data-file)"), nil)
 			}
 
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/inbox-placement-analytics/deliverability-insights", nil, body)
@@ -402,7 +398,7 @@ func newInboxPlacementAnalyticsStatsByDateCmd() *cobra.Command {
 		Use:   "stats-by-date",
 		Short: "Stats by date (POST /inbox-placement-analytics/stats-by-date)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.analytics.stats_by_date", err, nil)
 			}
@@ -425,7 +421,7 @@ func newInboxPlacementAnalyticsStatsByDateCmd() *cobra.Command {
 				body["end_date"] = strings.TrimSpace(endDate)
 			}
 			if strings.TrimSpace(fmt.Sprint(body["test_id"])) == "" || body["test_id"] == nil {
-				return printError(cmd, "inbox_placement.analytics.stats_by_date", fmt.Errorf("missing required test_id (set --test-id or set test_id in --data-json/--data-file)"), nil)
+				return printError(cmd, "inbox_placement.analytics.stats_by_date", usageErrorf("missing required test_id (set --test-id or set test_id in --data-json/--data-file)"), nil)
 			}
 
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/inbox-placement-analytics/stats-by-date", nil, body)
@@ -459,13 +455,14 @@ func newInboxPlacementReportsListCmd() *cobra.Command {
 		testID        string
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List inbox placement reports (GET /inbox-placement-reports; requires test_id)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.reports.list", err, nil)
 			}
@@ -483,18 +480,15 @@ func newInboxPlacementReportsListCmd() *cobra.Command {
 				return printError(cmd, "inbox_placement.reports.list", err, nil)
 			}
 			if strings.TrimSpace(q.Get("test_id")) == "" {
-				return printError(cmd, "inbox_placement.reports.list", fmt.Errorf("missing required test_id (set --test-id or pass --query test_id=...)"), nil)
-			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/inbox-placement-reports", q)
-			if err != nil {
-				return printError(cmd, "inbox_placement.reports.list", err, metaFrom(meta, nil))
+				return printError(cmd, "inbox_placement.reports.list", usageErrorf("missing required test_id (set --test-id or pass --query test_id=...)"), nil)
 			}
-			return printResult(cmd, "inbox_placement.reports.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "inbox_placement.reports.list", "/inbox-placement-reports", q, pages)
 		},
 	}
 	cmd.Flags().StringVar(&testID, "test-id", "", "Inbox placement test ID (required unless provided via --query test_id=...)")
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -505,13 +499,13 @@ func newInboxPlacementReportsGetCmd() *cobra.Command {
 		Short: "Get inbox placement report (GET /inbox-placement-reports/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "inbox_placement.reports.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "inbox_placement.reports.get", fmt.Errorf("report_id is required"), nil)
+				return printError(cmd, "inbox_placement.reports.get", usageErrorf("report_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/inbox-placement-reports/"+url.PathEscape(id), nil)
 			if err != nil {
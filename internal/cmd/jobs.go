@@ -1,11 +1,14 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
 	"net/url"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/instantly"
+	"github.com/salmonumbrella/instantly-cli/internal/api"
 )
 
 func newJobsCmd() *cobra.Command {
@@ -25,6 +28,7 @@ func newJobsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 
@@ -33,32 +37,32 @@ func newJobsListCmd() *cobra.Command {
 		Aliases: []string{"ls"},
 		Short:   "List background jobs",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "jobs.list", err, nil)
 			}
 
-			q := url.Values{}
-			if limit > 0 {
-				q.Set("limit", fmt.Sprintf("%d", limit))
-			}
-			if startingAfter != "" {
-				q.Set("starting_after", startingAfter)
-			}
-			if err := applyQueryPairs(q, queryPairs); err != nil {
+			params := instantly.ListBackgroundJobsParams{ListParams: instantly.ListParams{
+				Limit:         limit,
+				StartingAfter: startingAfter,
+				Query:         url.Values{},
+			}}
+			if err := applyQueryPairs(params.Query, queryPairs); err != nil {
 				return printError(cmd, "jobs.list", err, nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/background-jobs", q)
-			if err != nil {
-				return printError(cmd, "jobs.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "jobs.list", resp, metaFrom(meta, resp))
+			return printList(cmd, "jobs.list", pages, func(ctx context.Context, cursor string) (any, *api.Meta, error) {
+				if cursor != "" {
+					params.StartingAfter = cursor
+				}
+				return sdkPage(sdk.BackgroundJobs.List(ctx, params))
+			})
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination (use response next_starting_after)")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 
 	return cmd
@@ -71,20 +75,20 @@ func newJobsGetCmd() *cobra.Command {
 		Short:   "Get background job by ID",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "jobs.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "jobs.get", fmt.Errorf("job_id is required"), nil)
+				return printError(cmd, "jobs.get", usageErrorf("job_id is required"), nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/background-jobs/"+url.PathEscape(id), nil)
+			job, meta, err := sdk.BackgroundJobs.Get(cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "jobs.get", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "jobs.get", resp, metaFrom(meta, resp))
+			return printResult(cmd, "jobs.get", job.Raw, metaFrom(meta, job.Raw))
 		},
 	}
 	return cmd
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/internal/config"
+	"github.com/salmonumbrella/instantly-cli/internal/filter"
+)
+
+// jqOptions carries --jq-arg/--jq-argjson variables and the query library for --jq.
+var jqOptions filter.Options
+
+var jqVarNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// defaultJQLib is the saved-query directory: INSTANTLY_JQ_LIB, else <config dir>/jq.
+func defaultJQLib() string {
+	if v := strings.TrimSpace(os.Getenv("INSTANTLY_JQ_LIB")); v != "" {
+		return v
+	}
+	dir, err := config.Dir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "jq")
+}
+
+// loadJQOptions parses --jq-arg name=value and --jq-argjson name=json.
+func loadJQOptions() (filter.Options, error) {
+	opts := filter.Options{}
+	if flags.JQLib != "" {
+		opts.ModulePaths = []string{flags.JQLib}
+	}
+	bind := func(flag, spec string, parse func(string) (any, error)) error {
+		name, raw, ok := strings.Cut(spec, "=")
+		if !ok || !jqVarNameRe.MatchString(name) {
+			return usageErrorf("invalid %s %q (expected name=value)", flag, spec)
+		}
+		if _, dup := opts.Vars[name]; dup {
+			return usageErrorf("jq variable $%s is set more than once", name)
+		}
+		v, err := parse(raw)
+		if err != nil {
+			return usageErrorf("invalid %s %q: %w", flag, spec, err)
+		}
+		if opts.Vars == nil {
+			opts.Vars = map[string]any{}
+		}
+		opts.Vars[name] = v
+		return nil
+	}
+	for _, spec := range flags.JQArgs {
+		if err := bind("--jq-arg", spec, func(s string) (any, error) { return s, nil }); err != nil {
+			return filter.Options{}, err
+		}
+	}
+	for _, spec := range flags.JQArgJSON {
+		err := bind("--jq-argjson", spec, func(s string) (any, error) {
+			var v any
+			err := jsonUnmarshal([]byte(s), &v)
+			return v, err
+		})
+		if err != nil {
+			return filter.Options{}, err
+		}
+	}
+	return opts, nil
+}
+
+// applyJQ runs expr over v with the --jq-arg variables and the query library.
+func applyJQ(v any, expr string) (any, error) {
+	v, err := normalizeForJQ(v)
+	if err != nil {
+		return nil, err
+	}
+	// A failing --jq is the caller's expression at fault, not the API.
+	out, err := filter.ApplyWith(v, expr, jqOptions)
+	return out, usageError(err)
+}
+
+// printJSONValue applies --jq (if any) to value and prints it with print. With
+// --raw, each jq output is printed separately and strings are written unquoted,
+// like jq -r.
+func printJSONValue(cmd *cobra.Command, kind string, value any, jqExpr string, meta map[string]any, print func(io.Writer, any) error) error {
+	if !flags.Raw {
+		if jqExpr != "" {
+			v, err := applyJQ(value, jqExpr)
+			if err != nil {
+				return printError(cmd, kind, err, meta)
+			}
+			value = v
+		}
+		return print(cmd.OutOrStdout(), value)
+	}
+
+	outputs := []any{value}
+	if jqExpr != "" {
+		v, err := normalizeForJQ(value)
+		if err == nil {
+			outputs, err = filter.ApplyAll(v, jqExpr, jqOptions)
+			err = usageError(err)
+		}
+		if err != nil {
+			return printError(cmd, kind, err, meta)
+		}
+	}
+	for _, out := range outputs {
+		var err error
+		if s, ok := out.(string); ok {
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), s)
+		} else {
+			err = print(cmd.OutOrStdout(), out)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
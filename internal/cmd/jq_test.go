@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const jqAccountsBody = `{"items":[{"email":"a@x.com","status":1,"daily_limit":50},{"email":"b@x.com","status":2,"daily_limit":10}]}`
+
+func TestJQ_ArgsAndRaw(t *testing.T) {
+	srv := jsonServer(t, jqAccountsBody)
+
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "json", "-r",
+		"--jq", `.items[] | select(.email != $skip and .daily_limit >= $min) | .email`,
+		"--jq-arg", `skip=b@x.com"; halt`, "--jq-argjson", "min=10", "accounts", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+	if got := string(res.Stdout); got != "a@x.com\nb@x.com\n" {
+		t.Fatalf("stdout=%q", got)
+	}
+
+	// jsonl applies --jq per item; --raw unquotes each result.
+	res = execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "jsonl", "-r",
+		"--jq", `.email`, "accounts", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v", res.Err)
+	}
+	if got := string(res.Stdout); got != "a@x.com\nb@x.com\n" {
+		t.Fatalf("stdout=%q", got)
+	}
+
+	// Without --raw several outputs are still collected into one array.
+	res = execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "json", "--jq", `.items[].status`, "accounts", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v", res.Err)
+	}
+	if got := mustJSON(t, res.Stdout).([]any); len(got) != 2 {
+		t.Fatalf("got=%#v", got)
+	}
+}
+
+func TestJQ_SavedQueryLibrary(t *testing.T) {
+	srv := jsonServer(t, jqAccountsBody)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "team.jq"), []byte(`def low_limit($n): .items[] | select(.daily_limit < $n) | .email;`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("INSTANTLY_JQ_LIB", dir)
+
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "json", "-r",
+		"--jq", `import "team" as t; t::low_limit($n)`, "--jq-argjson", "n=20", "accounts", "list")
+	if res.Err != nil || string(res.Stdout) != "b@x.com\n" {
+		t.Fatalf("err=%v stdout=%q", res.Err, res.Stdout)
+	}
+
+	res = execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "json", "-r",
+		"--jq", `import "instantly" as i; i::active_senders | .email`, "accounts", "list")
+	if res.Err != nil || string(res.Stdout) != "a@x.com\n" {
+		t.Fatalf("err=%v stdout=%q", res.Err, res.Stdout)
+	}
+}
+
+func TestJQ_UsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"--jq-arg", "no-equals", "version"},
+		{"--jq-arg", "a=1", "--jq-argjson", "a=2", "version"},
+		{"--jq-argjson", "a={", "version"},
+		{"--raw", "--output", "yaml", "version"},
+	} {
+		res := execCLI(t, args...)
+		if res.Err == nil || ExitCode(res.Err) != 2 {
+			t.Fatalf("%v: err=%v", args, res.Err)
+		}
+	}
+}
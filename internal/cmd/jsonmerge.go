@@ -1,9 +1,6 @@
 package cmd
 
-import (
-	"encoding/json"
-	"fmt"
-)
+import "github.com/salmonumbrella/instantly-cli/internal/jsonnum"
 
 func readJSONObjectInput(dataJSON, dataFile string) (map[string]any, error) {
 	raw, err := readJSONInput(dataJSON, dataFile)
@@ -15,12 +12,12 @@ func readJSONObjectInput(dataJSON, dataFile string) (map[string]any, error) {
 	}
 
 	var v any
-	if err := json.Unmarshal(raw, &v); err != nil {
-		return nil, fmt.Errorf("invalid JSON: %w", err)
+	if err := jsonnum.Unmarshal(raw, &v); err != nil {
+		return nil, usageErrorf("invalid JSON: %w", err)
 	}
 	m, ok := v.(map[string]any)
 	if !ok {
-		return nil, fmt.Errorf("expected a JSON object")
+		return nil, usageErrorf("expected a JSON object")
 	}
 	return m, nil
 }
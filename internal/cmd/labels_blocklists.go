@@ -16,7 +16,7 @@ func normalizeLeadLabelInterestStatusLabel(s string) (string, error) {
 	case "":
 		return "", nil
 	default:
-		return "", fmt.Errorf("invalid interest status %q (allowed: positive|neutral|negative)", s)
+		return "", usageErrorf("invalid interest status %q (allowed: positive|neutral|negative)", s)
 	}
 }
 
@@ -40,6 +40,7 @@ func newBlockListEntriesListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		search        string
 		queryPairs    []string
 	)
@@ -47,7 +48,7 @@ func newBlockListEntriesListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List block list entries (GET /block-lists-entries)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "block_list_entries.list", err, nil)
 			}
@@ -64,15 +65,12 @@ func newBlockListEntriesListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "block_list_entries.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/block-lists-entries", q)
-			if err != nil {
-				return printError(cmd, "block_list_entries.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "block_list_entries.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "block_list_entries.list", "/block-lists-entries", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringVar(&search, "search", "", "Search")
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
@@ -84,13 +82,13 @@ func newBlockListEntriesGetCmd() *cobra.Command {
 		Short: "Get block list entry (GET /block-lists-entries/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "block_list_entries.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "block_list_entries.get", fmt.Errorf("entry_id is required"), nil)
+				return printError(cmd, "block_list_entries.get", usageErrorf("entry_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/block-lists-entries/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -111,7 +109,7 @@ func newBlockListEntriesCreateCmd() *cobra.Command {
 		Use:   "create",
 		Short: "Create block list entry (POST /block-lists-entries)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "block_list_entries.create", err, nil)
 			}
@@ -120,7 +118,7 @@ func newBlockListEntriesCreateCmd() *cobra.Command {
 				return printError(cmd, "block_list_entries.create", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "block_list_entries.create", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "block_list_entries.create", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			// Instantly expects "bl_value"; accept "value" as a convenient alias.
 			if v, ok := body["value"]; ok && body["bl_value"] == nil {
@@ -149,20 +147,20 @@ func newBlockListEntriesUpdateCmd() *cobra.Command {
 		Short: "Update block list entry (PATCH /block-lists-entries/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "block_list_entries.update", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "block_list_entries.update", fmt.Errorf("entry_id is required"), nil)
+				return printError(cmd, "block_list_entries.update", usageErrorf("entry_id is required"), nil)
 			}
 			body, err := readJSONObjectInput(dataJSON, dataFile)
 			if err != nil {
 				return printError(cmd, "block_list_entries.update", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "block_list_entries.update", fmt.Errorf("provide --data-json or --data-file with at least one field"), nil)
+				return printError(cmd, "block_list_entries.update", usageErrorf("provide --data-json or --data-file with at least one field"), nil)
 			}
 			// Instantly expects "bl_value"; accept "value" as a convenient alias.
 			if v, ok := body["value"]; ok && body["bl_value"] == nil {
@@ -189,15 +187,15 @@ func newBlockListEntriesDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "block_list_entries.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "block_list_entries.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "block_list_entries.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "block_list_entries.delete", fmt.Errorf("entry_id is required"), nil)
+				return printError(cmd, "block_list_entries.delete", usageErrorf("entry_id is required"), nil)
 			}
 			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/block-lists-entries/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -230,6 +228,7 @@ func newLeadLabelsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		search        string
 		queryPairs    []string
 	)
@@ -237,7 +236,7 @@ func newLeadLabelsListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List lead labels (GET /lead-labels)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "lead_labels.list", err, nil)
 			}
@@ -254,15 +253,12 @@ func newLeadLabelsListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "lead_labels.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/lead-labels", q)
-			if err != nil {
-				return printError(cmd, "lead_labels.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "lead_labels.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "lead_labels.list", "/lead-labels", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringVar(&search, "search", "", "Search")
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
@@ -274,13 +270,13 @@ func newLeadLabelsGetCmd() *cobra.Command {
 		Short: "Get lead label (GET /lead-labels/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "lead_labels.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "lead_labels.get", fmt.Errorf("label_id is required"), nil)
+				return printError(cmd, "lead_labels.get", usageErrorf("label_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/lead-labels/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -303,7 +299,7 @@ func newLeadLabelsCreateCmd() *cobra.Command {
 		Use:   "create",
 		Short: "Create lead label (POST /lead-labels)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "lead_labels.create", err, nil)
 			}
@@ -323,7 +319,7 @@ func newLeadLabelsCreateCmd() *cobra.Command {
 			}
 			val, ok := body["label"].(string)
 			if !ok || strings.TrimSpace(val) == "" {
-				return printError(cmd, "lead_labels.create", fmt.Errorf("label is required (use --name or set label in --data-json/--data-file)"), nil)
+				return printError(cmd, "lead_labels.create", usageErrorf("label is required (use --name or set label in --data-json/--data-file)"), nil)
 			}
 
 			// Required by the API: "interest_status_label" in {positive, neutral, negative}.
@@ -340,14 +336,14 @@ func newLeadLabelsCreateCmd() *cobra.Command {
 			if raw, ok := body["interest_status_label"]; ok && raw != nil {
 				s, ok := raw.(string)
 				if !ok {
-					return printError(cmd, "lead_labels.create", fmt.Errorf("interest_status_label must be a string"), nil)
+					return printError(cmd, "lead_labels.create", usageErrorf("interest_status_label must be a string"), nil)
 				}
 				norm, err := normalizeLeadLabelInterestStatusLabel(s)
 				if err != nil {
 					return printError(cmd, "lead_labels.create", err, nil)
 				}
 				if norm == "" {
-					return printError(cmd, "lead_labels.create", fmt.Errorf("interest_status_label is required (positive|neutral|negative)"), nil)
+					return printError(cmd, "lead_labels.create", usageErrorf("interest_status_label is required (positive|neutral|negative)"), nil)
 				}
 				body["interest_status_label"] = norm
 			}
@@ -378,13 +374,13 @@ func newLeadLabelsUpdateCmd() *cobra.Command {
 		Short: "Update lead label (PATCH /lead-labels/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "lead_labels.update", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "lead_labels.update", fmt.Errorf("label_id is required"), nil)
+				return printError(cmd, "lead_labels.update", usageErrorf("label_id is required"), nil)
 			}
 
 			body, err := readJSONObjectInput(dataJSON, dataFile)
@@ -414,19 +410,19 @@ func newLeadLabelsUpdateCmd() *cobra.Command {
 			if raw, ok := body["interest_status_label"]; ok && raw != nil {
 				s, ok := raw.(string)
 				if !ok {
-					return printError(cmd, "lead_labels.update", fmt.Errorf("interest_status_label must be a string"), nil)
+					return printError(cmd, "lead_labels.update", usageErrorf("interest_status_label must be a string"), nil)
 				}
 				norm, err := normalizeLeadLabelInterestStatusLabel(s)
 				if err != nil {
 					return printError(cmd, "lead_labels.update", err, nil)
 				}
 				if norm == "" {
-					return printError(cmd, "lead_labels.update", fmt.Errorf("interest_status_label must be positive|neutral|negative"), nil)
+					return printError(cmd, "lead_labels.update", usageErrorf("interest_status_label must be positive|neutral|negative"), nil)
 				}
 				body["interest_status_label"] = norm
 			}
 			if len(body) == 0 {
-				return printError(cmd, "lead_labels.update", fmt.Errorf("no fields to update"), nil)
+				return printError(cmd, "lead_labels.update", usageErrorf("no fields to update"), nil)
 			}
 
 			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/lead-labels/"+url.PathEscape(id), nil, body)
@@ -451,15 +447,15 @@ func newLeadLabelsDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "lead_labels.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "lead_labels.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "lead_labels.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "lead_labels.delete", fmt.Errorf("label_id is required"), nil)
+				return printError(cmd, "lead_labels.delete", usageErrorf("label_id is required"), nil)
 			}
 			// Instantly validates a JSON schema on this DELETE; include the id in the body.
 			resp, meta, err := client.DeleteJSONWithBody(
@@ -1,11 +1,14 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
 	"net/url"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/instantly"
+	"github.com/salmonumbrella/instantly-cli/internal/api"
 )
 
 func newLeadListsCmd() *cobra.Command {
@@ -29,6 +32,7 @@ func newLeadListsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		search        string
 		queryPairs    []string
 	)
@@ -38,35 +42,33 @@ func newLeadListsListCmd() *cobra.Command {
 		Aliases: []string{"ls"},
 		Short:   "List lead lists",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "lead_lists.list", err, nil)
 			}
 
-			q := url.Values{}
-			if limit > 0 {
-				q.Set("limit", fmt.Sprintf("%d", limit))
-			}
-			if startingAfter != "" {
-				q.Set("starting_after", startingAfter)
-			}
-			if search != "" {
-				q.Set("search", search)
-			}
-			if err := applyQueryPairs(q, queryPairs); err != nil {
+			params := instantly.ListLeadListsParams{ListParams: instantly.ListParams{
+				Limit:         limit,
+				StartingAfter: startingAfter,
+				Search:        search,
+				Query:         url.Values{},
+			}}
+			if err := applyQueryPairs(params.Query, queryPairs); err != nil {
 				return printError(cmd, "lead_lists.list", err, nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/lead-lists", q)
-			if err != nil {
-				return printError(cmd, "lead_lists.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "lead_lists.list", resp, metaFrom(meta, resp))
+			return printList(cmd, "lead_lists.list", pages, func(ctx context.Context, cursor string) (any, *api.Meta, error) {
+				if cursor != "" {
+					params.StartingAfter = cursor
+				}
+				return sdkPage(sdk.LeadLists.List(ctx, params))
+			})
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination (use response next_starting_after)")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringVar(&search, "search", "", "Search lead lists")
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 
@@ -80,20 +82,20 @@ func newLeadListsGetCmd() *cobra.Command {
 		Short:   "Get lead list by ID",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "lead_lists.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "lead_lists.get", fmt.Errorf("list_id is required"), nil)
+				return printError(cmd, "lead_lists.get", usageErrorf("list_id is required"), nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/lead-lists/"+url.PathEscape(id), nil)
+			list, meta, err := sdk.LeadLists.Get(cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "lead_lists.get", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "lead_lists.get", resp, metaFrom(meta, resp))
+			return printResult(cmd, "lead_lists.get", list.Raw, metaFrom(meta, list.Raw))
 		},
 	}
 	return cmd
@@ -115,24 +117,24 @@ func newLeadListsCreateCmd() *cobra.Command {
 			}
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "lead_lists.create", err, nil)
 			}
 			if strings.TrimSpace(name) == "" {
-				return printError(cmd, "lead_lists.create", fmt.Errorf("--name is required"), nil)
+				return printError(cmd, "lead_lists.create", usageErrorf("--name is required"), nil)
 			}
 
-			body := map[string]any{"name": name}
+			req := instantly.CreateLeadListRequest{Name: name}
 			if enrichSet {
-				body["has_enrichment_task"] = enrich
+				req.HasEnrichmentTask = instantly.Ptr(enrich)
 			}
 
-			resp, meta, err := client.PostJSON(cmdContext(cmd), "/lead-lists", nil, body)
+			list, meta, err := sdk.LeadLists.Create(cmdContext(cmd), req)
 			if err != nil {
 				return printError(cmd, "lead_lists.create", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "lead_lists.create", resp, metaFrom(meta, resp))
+			return printResult(cmd, "lead_lists.create", list.Raw, metaFrom(meta, list.Raw))
 		},
 	}
 
@@ -158,31 +160,31 @@ func newLeadListsUpdateCmd() *cobra.Command {
 			}
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "lead_lists.update", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "lead_lists.update", fmt.Errorf("list_id is required"), nil)
+				return printError(cmd, "lead_lists.update", usageErrorf("list_id is required"), nil)
 			}
 
-			body := map[string]any{}
+			req := instantly.UpdateLeadListRequest{}
 			if cmd.Flags().Changed("name") {
-				body["name"] = name
+				req.Name = instantly.Ptr(name)
 			}
 			if enrichSet {
-				body["has_enrichment_task"] = enrich
+				req.HasEnrichmentTask = instantly.Ptr(enrich)
 			}
-			if len(body) == 0 {
-				return printError(cmd, "lead_lists.update", fmt.Errorf("no fields to update"), nil)
+			if req.Empty() {
+				return printError(cmd, "lead_lists.update", usageErrorf("no fields to update"), nil)
 			}
 
-			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/lead-lists/"+url.PathEscape(id), nil, body)
+			list, meta, err := sdk.LeadLists.Update(cmdContext(cmd), id, req)
 			if err != nil {
 				return printError(cmd, "lead_lists.update", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "lead_lists.update", resp, metaFrom(meta, resp))
+			return printResult(cmd, "lead_lists.update", list.Raw, metaFrom(meta, list.Raw))
 		},
 	}
 
@@ -199,22 +201,22 @@ func newLeadListsDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "lead_lists.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "lead_lists.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "lead_lists.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "lead_lists.delete", fmt.Errorf("list_id is required"), nil)
+				return printError(cmd, "lead_lists.delete", usageErrorf("list_id is required"), nil)
 			}
 
-			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/lead-lists/"+url.PathEscape(id), nil)
+			list, meta, err := sdk.LeadLists.Delete(cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "lead_lists.delete", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "lead_lists.delete", resp, metaFrom(meta, resp))
+			return printResult(cmd, "lead_lists.delete", list.Raw, metaFrom(meta, list.Raw))
 		},
 	}
 	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm destructive action")
@@ -228,13 +230,13 @@ func newLeadListsVerificationStatsCmd() *cobra.Command {
 		Short:   "Get email verification stats for a lead list",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "lead_lists.verification_stats", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "lead_lists.verification_stats", fmt.Errorf("list_id is required"), nil)
+				return printError(cmd, "lead_lists.verification_stats", usageErrorf("list_id is required"), nil)
 			}
 
 			path := "/lead-lists/" + url.PathEscape(id) + "/verification-stats"
@@ -1,11 +1,14 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
 	"net/url"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/instantly"
+	"github.com/salmonumbrella/instantly-cli/internal/api"
 )
 
 func newLeadsCmd() *cobra.Command {
@@ -31,6 +34,7 @@ func newLeadsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		campaign      string
 		listID        string
 		search        string
@@ -51,50 +55,42 @@ func newLeadsListCmd() *cobra.Command {
 			}
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "leads.list", err, nil)
 			}
 
-			body := map[string]any{}
+			params := instantly.ListLeadsParams{
+				Limit:         limit,
+				StartingAfter: startingAfter,
+				Campaign:      campaign,
+				ListID:        listID,
+				Status:        status,
+				Search:        search,
+			}
 			if strings.TrimSpace(bodyJSON) != "" || strings.TrimSpace(bodyFile) != "" {
 				m, err := readJSONObjectInput(bodyJSON, bodyFile)
 				if err != nil {
 					return printError(cmd, "leads.list", err, nil)
 				}
-				mergeMaps(body, m)
-			}
-
-			body["limit"] = limit
-			if startingAfter != "" {
-				body["starting_after"] = startingAfter
-			}
-			if campaign != "" {
-				body["campaign"] = campaign
-			}
-			if listID != "" {
-				body["list_id"] = listID
-			}
-			if status != "" {
-				body["status"] = status
-			}
-			if search != "" {
-				body["search"] = search
+				params.Extra = m
 			}
 			if distinctSet {
-				body["distinct_contacts"] = distinct
+				params.DistinctContacts = instantly.Ptr(distinct)
 			}
 
-			resp, meta, err := client.PostJSON(cmdContext(cmd), "/leads/list", nil, body)
-			if err != nil {
-				return printError(cmd, "leads.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "leads.list", resp, metaFrom(meta, resp))
+			return printList(cmd, "leads.list", pages, func(ctx context.Context, cursor string) (any, *api.Meta, error) {
+				if cursor != "" {
+					params.StartingAfter = cursor
+				}
+				return sdkPage(sdk.Leads.List(ctx, params))
+			})
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination (use response next_starting_after)")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringVar(&campaign, "campaign", "", "Filter by campaign ID")
 	cmd.Flags().StringVar(&listID, "list-id", "", "Filter by lead list ID")
 	cmd.Flags().StringVar(&status, "status", "", "Filter by status")
@@ -113,20 +109,20 @@ func newLeadsGetCmd() *cobra.Command {
 		Short:   "Get lead by ID",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "leads.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "leads.get", fmt.Errorf("lead_id is required"), nil)
+				return printError(cmd, "leads.get", usageErrorf("lead_id is required"), nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/leads/"+url.PathEscape(id), nil)
+			lead, meta, err := sdk.Leads.Get(cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "leads.get", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "leads.get", resp, metaFrom(meta, resp))
+			return printResult(cmd, "leads.get", lead.Raw, metaFrom(meta, lead.Raw))
 		},
 	}
 	return cmd
@@ -145,38 +141,30 @@ func newLeadsCreateCmd() *cobra.Command {
 		Use:   "create",
 		Short: "Create a lead",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "leads.create", err, nil)
 			}
 			if strings.TrimSpace(email) == "" {
-				return printError(cmd, "leads.create", fmt.Errorf("--email is required"), nil)
+				return printError(cmd, "leads.create", usageErrorf("--email is required"), nil)
 			}
 
-			body := map[string]any{
-				"email": email,
+			req := instantly.CreateLeadRequest{
+				Email:       email,
+				Campaign:    campaign,
+				FirstName:   first,
+				LastName:    last,
+				CompanyName: company,
 				// Agent-friendly defaults.
-				"skip_if_in_workspace": true,
-				"skip_if_in_campaign":  true,
-			}
-			if campaign != "" {
-				body["campaign"] = campaign
-			}
-			if first != "" {
-				body["first_name"] = first
-			}
-			if last != "" {
-				body["last_name"] = last
-			}
-			if company != "" {
-				body["company_name"] = company
+				SkipIfInWorkspace: true,
+				SkipIfInCampaign:  true,
 			}
 
-			resp, meta, err := client.PostJSON(cmdContext(cmd), "/leads", nil, body)
+			lead, meta, err := sdk.Leads.Create(cmdContext(cmd), req)
 			if err != nil {
 				return printError(cmd, "leads.create", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "leads.create", resp, metaFrom(meta, resp))
+			return printResult(cmd, "leads.create", lead.Raw, metaFrom(meta, lead.Raw))
 		},
 	}
 
@@ -201,34 +189,34 @@ func newLeadsUpdateCmd() *cobra.Command {
 		Short: "Update a lead (partial)",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "leads.update", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "leads.update", fmt.Errorf("lead_id is required"), nil)
+				return printError(cmd, "leads.update", usageErrorf("lead_id is required"), nil)
 			}
 
-			body := map[string]any{}
+			req := instantly.UpdateLeadRequest{}
 			if cmd.Flags().Changed("first-name") {
-				body["first_name"] = first
+				req.FirstName = instantly.Ptr(first)
 			}
 			if cmd.Flags().Changed("last-name") {
-				body["last_name"] = last
+				req.LastName = instantly.Ptr(last)
 			}
 			if cmd.Flags().Changed("company-name") {
-				body["company_name"] = company
+				req.CompanyName = instantly.Ptr(company)
 			}
-			if len(body) == 0 {
-				return printError(cmd, "leads.update", fmt.Errorf("no fields to update"), nil)
+			if req.Empty() {
+				return printError(cmd, "leads.update", usageErrorf("no fields to update"), nil)
 			}
 
-			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/leads/"+url.PathEscape(id), nil, body)
+			lead, meta, err := sdk.Leads.Update(cmdContext(cmd), id, req)
 			if err != nil {
 				return printError(cmd, "leads.update", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "leads.update", resp, metaFrom(meta, resp))
+			return printResult(cmd, "leads.update", lead.Raw, metaFrom(meta, lead.Raw))
 		},
 	}
 
@@ -247,22 +235,22 @@ func newLeadsDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "leads.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "leads.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "leads.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "leads.delete", fmt.Errorf("lead_id is required"), nil)
+				return printError(cmd, "leads.delete", usageErrorf("lead_id is required"), nil)
 			}
 
-			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/leads/"+url.PathEscape(id), nil)
+			lead, meta, err := sdk.Leads.Delete(cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "leads.delete", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "leads.delete", resp, metaFrom(meta, resp))
+			return printResult(cmd, "leads.delete", lead.Raw, metaFrom(meta, lead.Raw))
 		},
 	}
 	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm destructive action")
@@ -287,9 +275,9 @@ Example:
 `),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "leads.bulk_delete", fmt.Errorf("refusing to bulk delete without --confirm"), nil)
+				return printError(cmd, "leads.bulk_delete", errConfirmRequired("refusing to bulk delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "leads.bulk_delete", err, nil)
 			}
@@ -298,7 +286,7 @@ Example:
 				return printError(cmd, "leads.bulk_delete", err, nil)
 			}
 			if len(q) == 0 {
-				return printError(cmd, "leads.bulk_delete", fmt.Errorf("provide at least one --query filter"), nil)
+				return printError(cmd, "leads.bulk_delete", usageErrorf("provide at least one --query filter"), nil)
 			}
 			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/leads", q)
 			if err != nil {
@@ -323,9 +311,9 @@ func newLeadsMergeCmd() *cobra.Command {
 		Short: "Merge leads (POST /leads/merge, requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "leads.merge", fmt.Errorf("refusing to merge leads without --confirm"), nil)
+				return printError(cmd, "leads.merge", errConfirmRequired("refusing to merge leads without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "leads.merge", err, nil)
 			}
@@ -334,7 +322,7 @@ func newLeadsMergeCmd() *cobra.Command {
 				return printError(cmd, "leads.merge", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "leads.merge", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "leads.merge", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/leads/merge", nil, body)
 			if err != nil {
@@ -360,9 +348,9 @@ func newLeadsUpdateInterestStatusCmd() *cobra.Command {
 		Short: "Update lead interest status (POST /leads/update-interest-status, requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "leads.update_interest_status", fmt.Errorf("refusing to update interest status without --confirm"), nil)
+				return printError(cmd, "leads.update_interest_status", errConfirmRequired("refusing to update interest status without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "leads.update_interest_status", err, nil)
 			}
@@ -371,7 +359,7 @@ func newLeadsUpdateInterestStatusCmd() *cobra.Command {
 				return printError(cmd, "leads.update_interest_status", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "leads.update_interest_status", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "leads.update_interest_status", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/leads/update-interest-status", nil, body)
 			if err != nil {
@@ -9,10 +9,25 @@ func metaFrom(meta *api.Meta, resp any) map[string]any {
 	if meta != nil {
 		if meta.Request.URL != "" {
 			out["request_url"] = meta.Request.URL
+			out["request_method"] = meta.Request.Method
 		}
 		if meta.RateLimit != nil {
 			out["rate_limit"] = meta.RateLimit
 		}
+		if meta.Cache != "" {
+			out["cache"] = meta.Cache
+		}
+		if meta.Idempotency != nil {
+			out["idempotency"] = meta.Idempotency
+		}
+		if n := len(meta.Attempts); n > 0 {
+			out["attempts"] = n
+			out["attempt_log"] = meta.Attempts
+			out["total_backoff_ms"] = meta.TotalBackoffMS
+		}
+		if meta.RetryAfterMS > 0 {
+			out["retry_after_ms"] = meta.RetryAfterMS
+		}
 	}
 
 	if p := paginationFrom(resp); p != nil {
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"net/url"
 	"strings"
 
@@ -30,7 +29,7 @@ func newOAuthGoogleInitCmd() *cobra.Command {
 		Use:   "google-init",
 		Short: "Init Google OAuth (POST /oauth/google/init)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "oauth.google_init", err, nil)
 			}
@@ -59,7 +58,7 @@ func newOAuthMicrosoftInitCmd() *cobra.Command {
 		Use:   "microsoft-init",
 		Short: "Init Microsoft OAuth (POST /oauth/microsoft/init)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "oauth.microsoft_init", err, nil)
 			}
@@ -85,13 +84,13 @@ func newOAuthSessionStatusCmd() *cobra.Command {
 		Short: "Get OAuth session status (GET /oauth/session/status/{sessionId})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "oauth.session_status", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "oauth.session_status", fmt.Errorf("session_id is required"), nil)
+				return printError(cmd, "oauth.session_status", usageErrorf("session_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/oauth/session/status/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/instantly"
+	"github.com/salmonumbrella/instantly-cli/internal/api"
+	"github.com/salmonumbrella/instantly-cli/internal/outfmt"
+)
+
+// pageFlags holds the shared auto-pagination flags for list commands.
+type pageFlags struct {
+	All      bool
+	MaxItems int
+}
+
+func addPageFlags(cmd *cobra.Command, p *pageFlags) {
+	cmd.Flags().BoolVar(&p.All, "all", false, "Follow next_starting_after and return items from every page")
+	cmd.Flags().IntVar(&p.MaxItems, "max-items", 0, "Stop after N items across pages, counting only items that pass --where (implies --all)")
+}
+
+func (p pageFlags) enabled() bool { return p.All || p.MaxItems > 0 }
+
+// pageFetcher fetches one page. An empty cursor means "use the caller's own starting point".
+type pageFetcher func(ctx context.Context, cursor string) (any, *api.Meta, error)
+
+// printListGET runs a GET list endpoint, following cursors when --all/--max-items is set.
+func printListGET(cmd *cobra.Command, client *api.Client, kind, path string, q url.Values, p pageFlags) error {
+	return printList(cmd, kind, p, func(ctx context.Context, cursor string) (any, *api.Meta, error) {
+		if cursor != "" {
+			q.Set("starting_after", cursor)
+		}
+		return client.GetJSON(ctx, path, q)
+	})
+}
+
+// sdkPage adapts a typed SDK page to a pageFetcher result; output stays the raw API response.
+func sdkPage[T any](page *instantly.Page[T], meta *api.Meta, err error) (any, *api.Meta, error) {
+	if err != nil {
+		return nil, meta, err
+	}
+	return page.Raw, meta, nil
+}
+
+func printList(cmd *cobra.Command, kind string, p pageFlags, fetch pageFetcher) error {
+	ctx := cmdContext(cmd)
+
+	// In jsonl mode, items are written as they are decoded off the wire, so memory
+	// stays flat however many pages --all walks. --sort-by needs every item first.
+	stream := outfmt.ModeFrom(ctx) == outfmt.JSONL && flags.SortBy == ""
+	var (
+		count     int
+		truncated bool
+		streamErr error
+		// lastID is the id of the last emitted item: the resume point when
+		// --max-items stops in the middle of a page.
+		lastID string
+	)
+	if stream {
+		jqExpr, err := effectiveJQExpression()
+		if err != nil {
+			return printError(cmd, kind, err, nil)
+		}
+		ctx = api.WithItemStream(ctx, func(item any) error {
+			// --max-items counts emitted items, so --where runs first.
+			keep, err := matchesWhere(item)
+			if err != nil {
+				streamErr = printError(cmd, kind, err, nil)
+				return streamErr
+			}
+			if !keep {
+				return nil
+			}
+			if p.MaxItems > 0 && count >= p.MaxItems {
+				// Keep draining the page: its cursor is still needed.
+				truncated = true
+				return nil
+			}
+			count++
+			lastID = itemID(item)
+			streamErr = printStreamItem(cmd, kind, item, jqExpr)
+			return streamErr
+		})
+	}
+
+	if !p.enabled() {
+		resp, meta, err := fetch(ctx, "")
+		if streamErr != nil {
+			// Already reported by printStreamItem.
+			return streamErr
+		}
+		if err != nil {
+			return printError(cmd, kind, err, metaFrom(meta, nil))
+		}
+		if stream && isListResponse(resp) {
+			return printStreamMeta(cmd, metaFrom(meta, resp))
+		}
+		return printResult(cmd, kind, resp, metaFrom(meta, resp))
+	}
+
+	var (
+		items    []any
+		lastMeta *api.Meta
+		cursor   string
+		pages    int
+	)
+	for {
+		resp, meta, err := fetch(ctx, cursor)
+		if streamErr != nil {
+			return streamErr
+		}
+		if err != nil {
+			if pages > 0 && !stream && cancelReason(cmd) != "" {
+				// Interrupted (signal or --deadline): return what was collected so far.
+				return printCancelled(cmd, kind, mergedItems(items, cursor), paginationMeta(lastMeta, pages, count, true, cursor))
+			}
+			outMeta := metaFrom(meta, nil)
+			if pages > 0 {
+				if outMeta == nil {
+					outMeta = map[string]any{}
+				}
+				outMeta["pagination"] = map[string]any{"all": true, "pages": pages, "items": count}
+			}
+			return printError(cmd, kind, err, outMeta)
+		}
+		pages++
+		lastMeta = meta
+
+		m, ok := resp.(map[string]any)
+		pageItems, isList := m["items"].([]any)
+		if !ok || !isList {
+			// Not a list response: nothing to merge, print the page as-is.
+			if pages == 1 {
+				return printResult(cmd, kind, resp, metaFrom(meta, resp))
+			}
+			break
+		}
+
+		// Streamed pages arrive with their items already printed (and an empty array here).
+		// Like the stream, --where filters before --max-items counts.
+		for _, item := range pageItems {
+			keep, err := matchesWhere(item)
+			if err != nil {
+				return printError(cmd, kind, err, nil)
+			}
+			if !keep {
+				continue
+			}
+			if p.MaxItems > 0 && count >= p.MaxItems {
+				truncated = true
+				break
+			}
+			count++
+			lastID = itemID(item)
+			items = append(items, item)
+		}
+
+		next := ""
+		if pg := paginationFrom(resp); pg != nil {
+			next, _ = pg["next_starting_after"].(string)
+		}
+		if truncated {
+			// Stopped mid-page: the API's cursors are item ids, so resume after
+			// the last item emitted.
+			cursor = lastID
+			break
+		}
+		if p.MaxItems > 0 && count >= p.MaxItems && next != "" {
+			// Stopped exactly on a page boundary: the next cursor is still a valid resume point.
+			truncated = true
+			cursor = next
+			break
+		}
+		// Guard against endpoints that echo the same cursor forever.
+		if next == "" || next == cursor {
+			cursor = ""
+			break
+		}
+		cursor = next
+	}
+
+	if stream {
+		return printStreamMeta(cmd, paginationMeta(lastMeta, pages, count, truncated, cursor))
+	}
+
+	return printResult(cmd, kind, mergedItems(items, cursor), paginationMeta(lastMeta, pages, count, truncated, cursor))
+}
+
+func mergedItems(items []any, cursor string) map[string]any {
+	merged := map[string]any{"items": items}
+	if items == nil {
+		merged["items"] = []any{}
+	}
+	if cursor != "" {
+		merged["next_starting_after"] = cursor
+	}
+	return merged
+}
+
+func paginationMeta(lastMeta *api.Meta, pages, count int, truncated bool, cursor string) map[string]any {
+	pagination := map[string]any{
+		"all":       true,
+		"pages":     pages,
+		"items":     count,
+		"truncated": truncated,
+		"has_more":  cursor != "" || truncated,
+	}
+	if cursor != "" {
+		pagination["next_starting_after"] = cursor
+	}
+	outMeta := metaFrom(lastMeta, nil)
+	if outMeta == nil {
+		outMeta = map[string]any{}
+	}
+	outMeta["pagination"] = pagination
+	return outMeta
+}
+
+// itemID returns a list item's id, or "" when it has none.
+func itemID(item any) string {
+	m, _ := item.(map[string]any)
+	return stringField(m, "id")
+}
+
+func printStreamItem(cmd *cobra.Command, kind string, item any, jqExpr string) error {
+	return printJSONValue(cmd, kind, item, jqExpr, nil, outfmt.PrintJSONL)
+}
+
+// printStreamMeta ends a jsonl item stream with a {"_meta": ...} record when --jsonl-meta is set.
+func printStreamMeta(cmd *cobra.Command, meta map[string]any) error {
+	if !flags.JSONLMeta || meta == nil {
+		return nil
+	}
+	return outfmt.PrintJSONL(cmd.OutOrStdout(), map[string]any{"_meta": meta})
+}
+
+// isListResponse reports whether resp is an object with an "items" array.
+func isListResponse(resp any) bool {
+	m, ok := resp.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, ok = m["items"].([]any)
+	return ok
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// pagedServer serves three pages of two items each, keyed by the starting_after cursor
+// (query string for GET, JSON body for POST).
+func pagedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	pages := map[string]string{
+		"":   `{"items":[{"id":"1"},{"id":"2"}],"next_starting_after":"c1"}`,
+		"c1": `{"items":[{"id":"3"},{"id":"4"}],"next_starting_after":"c2"}`,
+		"c2": `{"items":[{"id":"5"},{"id":"6"}]}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		cursor := r.URL.Query().Get("starting_after")
+		if r.Method == http.MethodPost {
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			cursor, _ = body["starting_after"].(string)
+		}
+		page, ok := pages[cursor]
+		if !ok {
+			w.WriteHeader(400)
+			_, _ = w.Write([]byte(`{"message":"bad cursor"}`))
+			return
+		}
+		_, _ = w.Write([]byte(page))
+	}))
+}
+
+func itemIDs(t *testing.T, items []any) string {
+	t.Helper()
+	ids := make([]string, 0, len(items))
+	for _, it := range items {
+		ids = append(ids, it.(map[string]any)["id"].(string))
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestListAll_GETMergesPages(t *testing.T) {
+	srv := pagedServer(t)
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "campaigns", "list", "--all")
+	if res.Err != nil {
+		t.Fatalf("err=%v stdout=%q", res.Err, string(res.Stdout))
+	}
+	out := mustJSON(t, res.Stdout).(map[string]any)
+	if got := itemIDs(t, out["items"].([]any)); got != "1,2,3,4,5,6" {
+		t.Fatalf("items=%s", got)
+	}
+	p := out["meta"].(map[string]any)["pagination"].(map[string]any)
+	if p["pages"] != float64(3) || p["truncated"] != false || p["has_more"] != false {
+		t.Fatalf("pagination=%#v", p)
+	}
+}
+
+func TestListAll_POSTBodyCursor(t *testing.T) {
+	srv := pagedServer(t)
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "--output", "json", "leads", "list", "--all")
+	if res.Err != nil {
+		t.Fatalf("err=%v stdout=%q", res.Err, string(res.Stdout))
+	}
+	out := mustJSON(t, res.Stdout).(map[string]any)
+	if got := itemIDs(t, out["items"].([]any)); got != "1,2,3,4,5,6" {
+		t.Fatalf("items=%s", got)
+	}
+}
+
+func TestListAll_MaxItemsTruncates(t *testing.T) {
+	srv := pagedServer(t)
+	defer srv.Close()
+
+	// Mid-page truncation resumes after the last item emitted.
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "accounts", "list", "--max-items", "3")
+	if res.Err != nil {
+		t.Fatalf("err=%v stdout=%q", res.Err, string(res.Stdout))
+	}
+	out := mustJSON(t, res.Stdout).(map[string]any)
+	if got := itemIDs(t, out["items"].([]any)); got != "1,2,3" {
+		t.Fatalf("items=%s", got)
+	}
+	p := out["meta"].(map[string]any)["pagination"].(map[string]any)
+	if p["truncated"] != true || p["pages"] != float64(2) || p["has_more"] != true || p["next_starting_after"] != "3" {
+		t.Fatalf("pagination=%#v", p)
+	}
+
+	// Page-boundary truncation keeps the resume cursor.
+	res = execCLI(t, "--base-url", srv.URL, "--api-key", "k", "accounts", "list", "--max-items", "4")
+	if res.Err != nil {
+		t.Fatalf("err=%v stdout=%q", res.Err, string(res.Stdout))
+	}
+	out = mustJSON(t, res.Stdout).(map[string]any)
+	p = out["meta"].(map[string]any)["pagination"].(map[string]any)
+	if p["truncated"] != true || p["has_more"] != true || p["next_starting_after"] != "c2" {
+		t.Fatalf("pagination=%#v", p)
+	}
+}
+
+func TestListAll_MaxItemsCountsItemsPassingWhere(t *testing.T) {
+	srv := pagedServer(t)
+	defer srv.Close()
+
+	for _, output := range []string{"agent", "jsonl"} {
+		res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "--output", output, "--jsonl-meta",
+			"--where", "id ~ ^[2456]$", "jobs", "list", "--max-items", "2")
+		if res.Err != nil {
+			t.Fatalf("%s: err=%v stdout=%q", output, res.Err, string(res.Stdout))
+		}
+		var (
+			items []any
+			p     map[string]any
+		)
+		if output == "agent" {
+			out := mustJSON(t, res.Stdout).(map[string]any)
+			items = out["items"].([]any)
+			p = out["meta"].(map[string]any)["pagination"].(map[string]any)
+		} else {
+			lines := strings.Split(strings.TrimSpace(string(res.Stdout)), "\n")
+			for _, l := range lines[:len(lines)-1] {
+				items = append(items, mustJSON(t, []byte(l)))
+			}
+			p = mustJSON(t, []byte(lines[len(lines)-1])).(map[string]any)["_meta"].(map[string]any)["pagination"].(map[string]any)
+		}
+		if got := itemIDs(t, items); got != "2,4" {
+			t.Fatalf("%s: items=%s", output, got)
+		}
+		if p["items"] != float64(2) || p["truncated"] != true || p["next_starting_after"] != "c2" {
+			t.Fatalf("%s: pagination=%#v", output, p)
+		}
+	}
+}
+
+func TestListAll_JSONLStreamsItems(t *testing.T) {
+	srv := pagedServer(t)
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "--output", "jsonl", "--fields", "id", "jobs", "list", "--all")
+	if res.Err != nil {
+		t.Fatalf("err=%v stdout=%q", res.Err, string(res.Stdout))
+	}
+	lines := strings.Split(strings.TrimSpace(string(res.Stdout)), "\n")
+	if len(lines) != 6 || lines[0] != `{"id":"1"}` || lines[5] != `{"id":"6"}` {
+		t.Fatalf("lines=%q", lines)
+	}
+}
+
+func TestListAll_ErrorMidway(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("starting_after") == "" {
+			_, _ = w.Write([]byte(`{"items":[{"id":"1"}],"next_starting_after":"c1"}`))
+			return
+		}
+		w.WriteHeader(500)
+		_, _ = w.Write([]byte(`{"message":"boom"}`))
+	}))
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "--output", "json", "webhooks", "events", "list", "--all")
+	if res.Err == nil {
+		t.Fatalf("expected error")
+	}
+	out := mustJSON(t, res.Stdout).(map[string]any)
+	p := out["meta"].(map[string]any)["pagination"].(map[string]any)
+	if p["pages"] != float64(1) {
+		t.Fatalf("pagination=%#v", p)
+	}
+}
+
+func TestList_JSONLOneRecordPerItemWithMeta(t *testing.T) {
+	srv := pagedServer(t)
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "--output", "jsonl", "--jsonl-meta", "jobs", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v stdout=%q", res.Err, string(res.Stdout))
+	}
+	lines := strings.Split(strings.TrimSpace(string(res.Stdout)), "\n")
+	if len(lines) != 3 || lines[0] != `{"id":"1"}` || lines[1] != `{"id":"2"}` {
+		t.Fatalf("lines=%q", lines)
+	}
+	last := mustJSON(t, []byte(lines[2])).(map[string]any)
+	p := last["_meta"].(map[string]any)["pagination"].(map[string]any)
+	if p["next_starting_after"] != "c1" {
+		t.Fatalf("meta record=%s", lines[2])
+	}
+}
+
+func TestListAll_JSONLMaxItemsMeta(t *testing.T) {
+	srv := pagedServer(t)
+	defer srv.Close()
+
+	res := execCLI(t, "--base-url", srv.URL, "--api-key", "k", "--output", "jsonl", "--jsonl-meta", "jobs", "list", "--max-items", "3")
+	if res.Err != nil {
+		t.Fatalf("err=%v stdout=%q", res.Err, string(res.Stdout))
+	}
+	lines := strings.Split(strings.TrimSpace(string(res.Stdout)), "\n")
+	if len(lines) != 4 || lines[2] != `{"id":"3"}` {
+		t.Fatalf("lines=%q", lines)
+	}
+	p := mustJSON(t, []byte(lines[3])).(map[string]any)["_meta"].(map[string]any)["pagination"].(map[string]any)
+	if p["items"] != float64(3) || p["truncated"] != true || p["pages"] != float64(2) || p["has_more"] != true || p["next_starting_after"] != "3" {
+		t.Fatalf("pagination=%#v", p)
+	}
+}
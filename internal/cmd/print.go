@@ -7,17 +7,24 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/salmonumbrella/instantly-cli/internal/agentfmt"
 	"github.com/salmonumbrella/instantly-cli/internal/api"
-	"github.com/salmonumbrella/instantly-cli/internal/filter"
+	"github.com/salmonumbrella/instantly-cli/internal/jsonnum"
 	"github.com/salmonumbrella/instantly-cli/internal/outfmt"
 )
 
 var jsonMarshal = json.Marshal
-var jsonUnmarshal = json.Unmarshal
+var jsonUnmarshal = jsonnum.Unmarshal
 
 func printResult(cmd *cobra.Command, kind string, resp any, meta map[string]any) error {
 	mode := outfmt.ModeFrom(cmd.Context())
+	resp, err := applyListFilter(resp)
+	if err != nil {
+		return printError(cmd, kind, err, meta)
+	}
+	if mode.Delimited() {
+		// --fields also picks and orders the columns there.
+		return printDelimited(cmd, kind, resp, meta, mode)
+	}
 
 	jqExpr, err := effectiveJQExpression()
 	if err != nil {
@@ -26,46 +33,39 @@ func printResult(cmd *cobra.Command, kind string, resp any, meta map[string]any)
 
 	switch mode {
 	case outfmt.Text:
-		// For now: keep text output minimal and predictable.
-		// Agents should use --output json/agent.
-		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "ok")
-		return nil
+		return printText(cmd, kind, resp, meta)
 	case outfmt.Agent:
-		value := agentfmt.Envelope(kind, resp, meta)
-		if jqExpr != "" {
-			value, err = normalizeForJQ(value)
-			if err == nil {
-				value, err = filter.Apply(value, jqExpr)
-			}
-			if err != nil {
-				return printError(cmd, kind, err, meta)
-			}
+		return printJSONValue(cmd, kind, envelope(cmd, kind, resp, meta), jqExpr, meta, outfmt.PrintJSON)
+	case outfmt.YAML, outfmt.Template:
+		value := resp
+		if flags.Envelope {
+			value = envelope(cmd, kind, resp, meta)
+		}
+		// Normalize to plain maps so templates and yaml see the JSON field names.
+		value, err = normalizeForJQ(value)
+		if err == nil && jqExpr != "" {
+			value, err = applyJQ(value, jqExpr)
 		}
-		return outfmt.PrintJSON(cmd.OutOrStdout(), value)
+		if err != nil {
+			return printError(cmd, kind, err, meta)
+		}
+		if mode == outfmt.YAML {
+			return outfmt.PrintYAML(cmd.OutOrStdout(), value)
+		}
+		return outfmt.PrintTemplate(cmd.OutOrStdout(), outputTemplate, value)
 	case outfmt.JSONL:
-		value := resp
-		if jqExpr != "" {
-			value, err = normalizeForJQ(value)
-			if err == nil {
-				value, err = filter.Apply(value, jqExpr)
-			}
-			if err != nil {
-				return printError(cmd, kind, err, meta)
+		if isListResponse(resp) {
+			// One record per item, like the streamed list path.
+			for _, it := range resp.(map[string]any)["items"].([]any) {
+				if err := printStreamItem(cmd, kind, it, jqExpr); err != nil {
+					return err
+				}
 			}
+			return printStreamMeta(cmd, meta)
 		}
-		return outfmt.PrintJSONL(cmd.OutOrStdout(), value)
+		return printJSONValue(cmd, kind, resp, jqExpr, meta, outfmt.PrintJSONL)
 	default:
-		value := resp
-		if jqExpr != "" {
-			value, err = normalizeForJQ(value)
-			if err == nil {
-				value, err = filter.Apply(value, jqExpr)
-			}
-			if err != nil {
-				return printError(cmd, kind, err, meta)
-			}
-		}
-		return outfmt.PrintJSON(cmd.OutOrStdout(), value)
+		return printJSONValue(cmd, kind, resp, jqExpr, meta, outfmt.PrintJSON)
 	}
 }
 
@@ -83,26 +83,56 @@ func normalizeForJQ(v any) (any, error) {
 	return out, nil
 }
 
+// errorReported is set once an error has been printed, so Execute does not
+// print it again.
+var errorReported bool
+
 func printError(cmd *cobra.Command, kind string, err error, meta map[string]any) error {
+	errorReported = true
 	mode := outfmt.ModeFrom(cmd.Context())
-	if mode == outfmt.Text {
-		// Text mode: print to stderr.
+	var (
+		apiErr  *api.APIError
+		details []fieldDetail
+	)
+	if errors.As(err, &apiErr) && apiErr != nil {
+		details = validationDetails(cmd, apiErr)
+	}
+
+	if mode == outfmt.Text || mode == outfmt.Template || mode.Delimited() {
+		// Text, template and csv/tsv: print to stderr so stdout stays parseable.
 		_, _ = fmt.Fprintln(cmd.ErrOrStderr(), err.Error())
+		for _, d := range details {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "  %s\n", d)
+		}
 		return err
 	}
 
+	meta = withCancelled(cmd, meta)
+
 	// Improve agent debuggability: include status code for API errors.
-	var apiErr *api.APIError
-	if errors.As(err, &apiErr) && apiErr != nil {
+	if apiErr != nil {
 		if meta == nil {
 			meta = map[string]any{}
 		}
 		meta["http_status"] = apiErr.Status
 	}
 
+	info := classifyError(err)
 	payload := map[string]any{
-		"kind":  kind,
-		"error": err.Error(),
+		"kind":      kind,
+		"error":     err.Error(),
+		"code":      info.Code,
+		"retryable": info.Retryable,
+	}
+	if info.RetryAfter > 0 {
+		payload["retry_after_ms"] = info.RetryAfter.Milliseconds()
+	}
+	if len(details) > 0 {
+		payload["details"] = details
+	}
+	var scopeErr *missingScopeError
+	if errors.As(err, &scopeErr) {
+		payload["missing_scopes"] = scopeErr.Missing
 	}
 	if meta != nil {
 		payload["meta"] = meta
@@ -110,9 +140,14 @@ func printError(cmd *cobra.Command, kind string, err error, meta map[string]any)
 
 	// Best effort: emit structured error to stdout then return original error for exit code.
 	// Even on failure, emitting JSON is useful for agents orchestrating retries.
-	if mode == outfmt.JSONL {
+	switch mode {
+	case outfmt.JSONL:
 		_ = outfmt.PrintJSONL(cmd.OutOrStdout(), payload)
-	} else {
+	case outfmt.YAML:
+		if v, nerr := normalizeForJQ(payload); nerr == nil {
+			_ = outfmt.PrintYAML(cmd.OutOrStdout(), v)
+		}
+	default:
 		_ = outfmt.PrintJSON(cmd.OutOrStdout(), payload)
 	}
 	return err
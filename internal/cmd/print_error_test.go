@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -36,3 +38,24 @@ func TestPrintError_IncludesHTTPStatusForAPIErrors(t *testing.T) {
 		t.Fatalf("expected http_status=500; got=%v", meta["http_status"])
 	}
 }
+
+func TestPrintError_SurfacesAttempts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`{"message":"upstream"}`))
+	}))
+	defer srv.Close()
+
+	res := execCLI(t, "jobs", "get", "j1", "--api-key", "k", "--base-url", srv.URL, "--max-5xx-retries", "1", "--retry-delay", "1ms", "--max-retry-delay", "1ms")
+	if res.Err == nil {
+		t.Fatalf("expected error")
+	}
+	meta := mustJSON(t, res.Stdout).(map[string]any)["meta"].(map[string]any)
+	log, _ := meta["attempt_log"].([]any)
+	if meta["attempts"] != float64(2) || len(log) != 2 {
+		t.Fatalf("meta=%#v", meta)
+	}
+	if first := log[0].(map[string]any); first["status"] != float64(502) || first["retry_reason"] != "server_error" {
+		t.Fatalf("first=%#v", first)
+	}
+}
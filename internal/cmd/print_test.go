@@ -65,7 +65,7 @@ func TestPrintResult_ModesAndFiltering(t *testing.T) {
 		if err := printResult(c, "k", map[string]any{"a": 1}, nil); err != nil {
 			t.Fatalf("err=%v", err)
 		}
-		if strings.TrimSpace(out.String()) != "ok" {
+		if strings.TrimSpace(out.String()) != "a  1" {
 			t.Fatalf("out=%q", out.String())
 		}
 	}
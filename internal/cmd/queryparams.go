@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"net/url"
 	"strings"
 )
@@ -15,7 +14,7 @@ func applyQueryPairs(q url.Values, pairs []string) error {
 		}
 		k, v, ok := strings.Cut(pair, "=")
 		if !ok || strings.TrimSpace(k) == "" {
-			return fmt.Errorf("invalid --query %q (expected key=value)", pair)
+			return usageErrorf("invalid --query %q (expected key=value)", pair)
 		}
 		q.Set(strings.TrimSpace(k), strings.TrimSpace(v))
 	}
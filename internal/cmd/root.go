@@ -2,17 +2,22 @@ package cmd
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/salmonumbrella/instantly-cli/instantly"
+	"github.com/salmonumbrella/instantly-cli/internal/agentfmt"
 	"github.com/salmonumbrella/instantly-cli/internal/api"
 	"github.com/salmonumbrella/instantly-cli/internal/outfmt"
+	"github.com/salmonumbrella/instantly-cli/internal/sdkbridge"
 )
 
 type rootFlags struct {
@@ -26,22 +31,91 @@ type rootFlags struct {
 	APIKey  string
 	DryRun  bool
 
+	// Deadline bounds the whole command (all requests, retries and polling).
+	Deadline time.Duration
+
 	JQ     string
 	Fields string
 
-	Max429Retries  int
-	Max5xxRetries  int
-	RetryDelay     time.Duration
-	MaxRetryDelay  time.Duration
-	IdempotencyKey string
+	// JQArgs/JQArgJSON bind $name variables for --jq; JQLib holds saved-query
+	// modules; Raw prints string results without JSON quotes.
+	JQArgs    []string
+	JQArgJSON []string
+	JQLib     string
+	Raw       bool
+
+	// Where/SortBy filter and order list items client-side.
+	Where  []string
+	SortBy string
+
+	DebugFormat string
+
+	// Columns and NoHeaders shape --output text tables.
+	Columns   string
+	NoHeaders bool
+
+	// Template/TemplateFile select --output template; Envelope wraps yaml and
+	// template output in the agent envelope.
+	Template     string
+	TemplateFile string
+	Envelope     bool
+
+	// JSONLMeta appends a trailing meta record to jsonl list output.
+	JSONLMeta bool
+
+	// EnvelopeVersion selects the agent envelope shape (1 or 2).
+	EnvelopeVersion int
+
+	Max429Retries      int
+	Max5xxRetries      int
+	RetryDelay         time.Duration
+	MaxRetryDelay      time.Duration
+	IdempotencyKey     string
+	AutoIdempotency    bool
+	IdempotencyJournal string
+	RateLimitState     string
+	NoRateLimit        bool
+
+	Record string
+	Replay string
+
+	CacheTTL time.Duration
+	CacheDir string
+
+	Headers            []string
+	CAFile             string
+	Proxy              string
+	InsecureSkipVerify bool
+
+	// Config is the config file path; Profile selects one of its profiles.
+	Config  string
+	Profile string
+
+	// Profiles/AllProfiles run the command once per profile and merge the results.
+	Profiles    []string
+	AllProfiles bool
+
+	// PreflightScopes checks the API key's scopes before the command runs.
+	PreflightScopes bool
 }
 
 var flags = rootFlags{
-	Output:  defaultOutput(),
-	Timeout: 60 * time.Second,
-	BaseURL: api.DefaultBaseURL,
+	Output:      defaultOutput(),
+	DebugFormat: "text",
+	Timeout:     60 * time.Second,
+	BaseURL:     api.DefaultBaseURL,
 }
 
+// debugOut receives --debug traces; it follows the running command's stderr.
+var debugOut io.Writer = os.Stderr
+
+// runCancel releases the --deadline timer once the command finishes.
+var runCancel context.CancelFunc = func() {}
+
+// commandPath is the running command (e.g. "instantly leads create"); it scopes
+// the --auto-idempotency journal.
+var commandPath string
+
 func defaultOutput() string {
 	v := strings.TrimSpace(os.Getenv("INSTANTLY_OUTPUT"))
 	if v != "" {
@@ -57,23 +131,62 @@ func resetFlagsToDefaults() {
 	flags.Quiet = false
 	flags.Silent = false
 	flags.Debug = false
+	flags.DebugFormat = "text"
 	flags.Timeout = 60 * time.Second
 	flags.BaseURL = api.DefaultBaseURL
 	flags.APIKey = strings.TrimSpace(os.Getenv("INSTANTLY_API_KEY"))
 	flags.DryRun = false
+	flags.Deadline = 0
 
 	flags.JQ = ""
 	flags.Fields = ""
+	flags.JQArgs = nil
+	flags.JQArgJSON = nil
+	flags.JQLib = defaultJQLib()
+	flags.Raw = false
+	flags.Where = nil
+	flags.SortBy = ""
+	flags.Columns = ""
+	flags.NoHeaders = false
+	flags.Template = ""
+	flags.TemplateFile = ""
+	flags.Envelope = false
+	flags.JSONLMeta = false
+	flags.EnvelopeVersion = defaultEnvelopeVersion()
 
 	flags.Max429Retries = 0
 	flags.Max5xxRetries = 0
 	flags.RetryDelay = 1 * time.Second
 	flags.MaxRetryDelay = 30 * time.Second
 	flags.IdempotencyKey = ""
+	flags.AutoIdempotency = false
+	flags.IdempotencyJournal = ""
+	flags.RateLimitState = strings.TrimSpace(os.Getenv("INSTANTLY_RATE_LIMIT_STATE"))
+	flags.NoRateLimit, _ = strconv.ParseBool(strings.TrimSpace(os.Getenv("INSTANTLY_NO_RATE_LIMIT")))
+
+	flags.Record = ""
+	flags.Replay = ""
+
+	flags.CacheTTL = 0
+	flags.CacheDir = ""
+
+	flags.Headers = nil
+	flags.CAFile = ""
+	flags.Proxy = ""
+	flags.InsecureSkipVerify = false
+
+	flags.Config = defaultConfigPath()
+	flags.Profile = strings.TrimSpace(os.Getenv("INSTANTLY_PROFILE"))
+
+	flags.Profiles = nil
+	flags.AllProfiles = false
+
+	flags.PreflightScopes = defaultPreflightScopes()
 }
 
 func newRootCmd() *cobra.Command {
 	resetFlagsToDefaults()
+	errorReported = false
 
 	cmd := &cobra.Command{
 		Use:           "instantly",
@@ -81,28 +194,72 @@ func newRootCmd() *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			// --profiles/--all-profiles rerun the command per profile instead;
+			// otherwise profile values fill in whatever flags and env left unset.
+			profiles, err := fanOutProfiles(cmd)
+			if err != nil {
+				return err
+			}
+			if profiles != nil {
+				cmd.RunE = fanOutRunE(profiles)
+			} else if err := applyProfile(cmd); err != nil {
+				return err
+			}
 			// --json is just a shorthand for --output json.
 			if flags.JSON {
 				if cmd.Flags().Changed("output") && flags.Output != "json" {
-					return fmt.Errorf("--json conflicts with --output %s", flags.Output)
+					return usageErrorf("--json conflicts with --output %s", flags.Output)
 				}
 				flags.Output = "json"
 			}
+			// Likewise --template/--template-file imply --output template.
+			if flags.Template != "" || flags.TemplateFile != "" {
+				if flags.Template != "" && flags.TemplateFile != "" {
+					return usageErrorf("--template and --template-file cannot be used together")
+				}
+				if cmd.Flags().Changed("output") && flags.Output != "template" {
+					return usageErrorf("--template conflicts with --output %s", flags.Output)
+				}
+				flags.Output = "template"
+			}
 
 			mode, err := outfmt.ParseMode(flags.Output)
 			if err != nil {
+				return usageError(err)
+			}
+			if mode == outfmt.Template {
+				if outputTemplate, err = loadOutputTemplate(); err != nil {
+					return err
+				}
+			}
+			if err := validateEnvelopeVersion(flags.EnvelopeVersion); err != nil {
+				return err
+			}
+			if jqOptions, err = loadJQOptions(); err != nil {
+				return err
+			}
+			if err := compileListFilter(flags.Where, flags.SortBy); err != nil {
 				return err
 			}
+			if flags.DebugFormat != "text" && flags.DebugFormat != "json" {
+				return usageErrorf("invalid --debug-format %q (expected text or json)", flags.DebugFormat)
+			}
+
+			commandPath = cmd.CommandPath()
+			commandStart = time.Now()
 
 			ctx := cmd.Context()
+			if flags.Deadline > 0 {
+				ctx, runCancel = context.WithTimeoutCause(ctx, flags.Deadline, errDeadline)
+			}
 			ctx = outfmt.WithMode(ctx, mode)
 			cmd.SetContext(ctx)
 
 			if strings.TrimSpace(flags.JQ) != "" || strings.TrimSpace(flags.Fields) != "" {
 				// Filtering only applies to JSON-ish outputs.
-				if mode != outfmt.JSON && mode != outfmt.JSONL && mode != outfmt.Agent {
+				if mode == outfmt.Text {
 					if cmd.Flags().Changed("output") {
-						return fmt.Errorf("--jq/--fields require --output json, jsonl, or agent (or omit --output)")
+						return usageErrorf("--jq/--fields require a structured --output (json, jsonl, agent, csv, tsv, yaml, template)")
 					}
 					flags.Output = "json"
 					mode = outfmt.JSON
@@ -110,11 +267,15 @@ func newRootCmd() *cobra.Command {
 					cmd.SetContext(ctx)
 				}
 			}
+			if flags.Raw && mode != outfmt.JSON && mode != outfmt.JSONL && mode != outfmt.Agent {
+				return usageErrorf("--raw requires --output json, jsonl or agent")
+			}
 
 			if flags.Silent {
 				cmd.SetOut(io.Discard)
 				cmd.SetErr(io.Discard)
-				return nil
+				debugOut = io.Discard
+				return preflightScopes(cmd)
 			}
 
 			if flags.Quiet {
@@ -125,26 +286,78 @@ func newRootCmd() *cobra.Command {
 				}
 			}
 
-			return nil
+			debugOut = cmd.ErrOrStderr()
+			return preflightScopes(cmd)
 		},
 	}
 
 	initRootFlagsAndCommands(cmd)
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error { return usageError(err) })
+	markArgErrorsUsage(cmd)
 	return cmd
 }
 
+// markArgErrorsUsage makes the argument-count errors of cmd and its
+// subcommands usage errors.
+func markArgErrorsUsage(cmd *cobra.Command) {
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(c *cobra.Command, a []string) error { return usageError(args(c, a)) }
+	}
+	for _, sub := range cmd.Commands() {
+		markArgErrorsUsage(sub)
+	}
+}
+
 // Execute runs the root command.
 func Execute() error {
+	// Ctrl-C / SIGTERM cancel the command context so it can stop cleanly and
+	// still print a final envelope. Once cancelled, the handler is released so a
+	// second Ctrl-C kills the process instead of being swallowed.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+	defer func() { runCancel() }()
+
 	cmd := newRootCmd()
-	cmd.SetContext(context.Background())
-	return cmd.Execute()
+	cmd.SetContext(ctx)
+	c, err := cmd.ExecuteC()
+	if err != nil && c == cmd {
+		// Only cobra's "unknown command" fails on the root itself.
+		err = usageError(err)
+	}
+	if err != nil && !errorReported {
+		reportUncaught(c, err)
+	}
+	return err
+}
+
+// reportUncaught prints errors that never reached printError, such as cobra's
+// flag and argument errors, in the requested output format.
+func reportUncaught(cmd *cobra.Command, err error) {
+	mode, perr := outfmt.ParseMode(flags.Output)
+	if perr != nil || flags.JSON {
+		mode = outfmt.JSON
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd.SetContext(outfmt.WithMode(ctx, mode))
+	_ = printError(cmd, agentfmt.KindFromCommandPath(cmd.CommandPath()), err, nil)
 }
 
 func cmdContext(cmd *cobra.Command) context.Context { return cmd.Context() }
 
-func clientFromFlags() (*api.Client, error) {
-	if strings.TrimSpace(flags.APIKey) == "" && !flags.DryRun {
-		return nil, errors.New("missing API key: set INSTANTLY_API_KEY or pass --api-key")
+func clientFromFlags(ctx context.Context) (*api.Client, error) {
+	if flags.Record != "" && flags.Replay != "" {
+		return nil, usageErrorf("--record and --replay are mutually exclusive")
+	}
+	if strings.TrimSpace(flags.APIKey) == "" && !flags.DryRun && flags.Replay == "" {
+		key, err := fallbackAPIKey(ctx)
+		if err != nil {
+			return nil, err
+		}
+		flags.APIKey = key
 	}
 	c := api.NewClient(flags.BaseURL, flags.APIKey, flags.Timeout)
 	c.DryRun = flags.DryRun
@@ -153,28 +366,147 @@ func clientFromFlags() (*api.Client, error) {
 	c.RetryDelay = flags.RetryDelay
 	c.MaxRetryDelay = flags.MaxRetryDelay
 	c.IdempotencyKey = flags.IdempotencyKey
+	if flags.Debug {
+		c.Trace = api.NewDebugTracer(debugOut, flags.DebugFormat)
+	}
+	if opts := (api.TransportOptions{CAFile: flags.CAFile, Proxy: flags.Proxy, InsecureSkipVerify: flags.InsecureSkipVerify}); !opts.Empty() {
+		t, err := api.NewTransport(opts)
+		if err != nil {
+			return nil, err
+		}
+		c.HTTPClient.Transport = t
+	}
+	if len(flags.Headers) > 0 {
+		h, err := api.ParseHeaders(flags.Headers)
+		if err != nil {
+			return nil, usageErrorf("--header: %w", err)
+		}
+		c.Use(api.WithHeaders(h))
+	}
+	if !flags.NoRateLimit {
+		state := strings.TrimSpace(flags.RateLimitState)
+		if state == "auto" {
+			p, err := api.DefaultRateLimitStatePath(flags.APIKey)
+			if err != nil {
+				return nil, fmt.Errorf("resolve --rate-limit-state: %w", err)
+			}
+			state = p
+		}
+		c.RateLimiter = api.NewRateLimiter(state)
+	}
+	if flags.AutoIdempotency && strings.TrimSpace(flags.IdempotencyKey) == "" {
+		path := flags.IdempotencyJournal
+		if path == "" {
+			p, err := api.DefaultJournalPath(flags.APIKey)
+			if err != nil {
+				return nil, fmt.Errorf("resolve --idempotency-journal: %w", err)
+			}
+			path = p
+		}
+		c.Journal = api.NewJournal(path, commandPath)
+	}
+	if flags.CacheTTL > 0 {
+		dir := flags.CacheDir
+		if dir == "" {
+			p, err := api.DefaultCacheDir()
+			if err != nil {
+				return nil, fmt.Errorf("resolve --cache-dir: %w", err)
+			}
+			dir = p
+		}
+		c.Cache = api.NewCache(dir, flags.APIKey, flags.CacheTTL)
+	}
+	switch {
+	case flags.Record != "":
+		rec, err := api.NewRecorder(flags.Record, c.HTTPClient.Transport)
+		if err != nil {
+			return nil, err
+		}
+		// --header often carries gateway tokens or cookies; keep them out of the cassette.
+		for _, pair := range flags.Headers {
+			name, _, _ := strings.Cut(pair, "=")
+			rec.Redact = append(rec.Redact, strings.TrimSpace(name))
+		}
+		c.HTTPClient.Transport = rec
+	case flags.Replay != "":
+		rep, err := api.NewReplayer(flags.Replay)
+		if err != nil {
+			return nil, err
+		}
+		c.HTTPClient.Transport = rep
+		// Replays are offline and deterministic: no key needed, no pacing on recorded headers.
+		if strings.TrimSpace(c.APIKey) == "" {
+			c.APIKey = "replay"
+		}
+		c.RateLimiter = nil
+	}
 	return c, nil
 }
 
+// sdkFromFlags returns the typed client used by resource commands; it shares
+// the transport (and therefore dry-run/retry settings) built by clientFromFlags.
+func sdkFromFlags(ctx context.Context) (*instantly.Client, error) {
+	c, err := clientFromFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sdkbridge.NewClient(c).(*instantly.Client), nil
+}
+
 func initRootFlagsAndCommands(rootCmd *cobra.Command) {
-	rootCmd.PersistentFlags().StringVarP(&flags.Output, "output", "o", flags.Output, "output format (text, json, jsonl, agent)")
+	rootCmd.PersistentFlags().StringVarP(&flags.Output, "output", "o", flags.Output, "output format (text, json, jsonl, agent, csv, tsv, yaml, template)")
 	rootCmd.PersistentFlags().BoolVar(&flags.JSON, "json", false, "shorthand for --output json")
 	rootCmd.PersistentFlags().BoolVar(&flags.Quiet, "quiet", false, "suppress stderr and text output")
 	rootCmd.PersistentFlags().BoolVar(&flags.Silent, "silent", false, "suppress all output (stdout and stderr)")
-	rootCmd.PersistentFlags().BoolVar(&flags.Debug, "debug", false, "debug logging (stderr)")
+	rootCmd.PersistentFlags().BoolVar(&flags.Debug, "debug", false, "Trace each HTTP attempt to stderr (Authorization is redacted)")
+	rootCmd.PersistentFlags().StringVar(&flags.DebugFormat, "debug-format", flags.DebugFormat, "--debug trace format: text or json (one object per line)")
 	rootCmd.PersistentFlags().DurationVar(&flags.Timeout, "timeout", flags.Timeout, "http timeout (e.g. 30s, 2m)")
+	rootCmd.PersistentFlags().DurationVar(&flags.Deadline, "deadline", 0, "Total wall time for the command across all requests, retries and polling (e.g. 2m; 0 = none)")
 	rootCmd.PersistentFlags().StringVar(&flags.BaseURL, "base-url", flags.BaseURL, "Instantly API base URL")
 	rootCmd.PersistentFlags().StringVar(&flags.APIKey, "api-key", flags.APIKey, "Instantly API key (or set INSTANTLY_API_KEY)")
+	rootCmd.PersistentFlags().StringVar(&flags.Config, "config", flags.Config, "Config file with named profiles (or set INSTANTLY_CONFIG)")
+	rootCmd.PersistentFlags().StringVar(&flags.Profile, "profile", flags.Profile, "Config profile to use (or set INSTANTLY_PROFILE)")
+	rootCmd.PersistentFlags().StringSliceVar(&flags.Profiles, "profiles", nil, "Run against each of these comma-separated profiles concurrently and merge the results")
+	rootCmd.PersistentFlags().BoolVar(&flags.AllProfiles, "all-profiles", false, "Like --profiles, with every profile in the config file")
+	rootCmd.PersistentFlags().BoolVar(&flags.PreflightScopes, "preflight-scopes", flags.PreflightScopes, "Check the API key's scopes (cached 1h) before running and fail early with missing_scope (or set INSTANTLY_PREFLIGHT_SCOPES=1)")
 	rootCmd.PersistentFlags().BoolVar(&flags.DryRun, "dry-run", false, "Do not make network calls; print the request that would be made")
 
 	rootCmd.PersistentFlags().StringVar(&flags.JQ, "jq", "", "JQ expression to filter JSON/agent output")
-	rootCmd.PersistentFlags().StringVar(&flags.Fields, "fields", "", "Comma-separated fields to select (shorthand for --jq)")
+	rootCmd.PersistentFlags().StringArrayVar(&flags.JQArgs, "jq-arg", nil, "Bind $name to a string in --jq: name=value (repeatable)")
+	rootCmd.PersistentFlags().StringArrayVar(&flags.JQArgJSON, "jq-argjson", nil, "Bind $name to a JSON value in --jq: name=json (repeatable)")
+	rootCmd.PersistentFlags().StringVar(&flags.JQLib, "jq-lib", flags.JQLib, "Directory of saved jq modules, used via import \"name\" as n; (or set INSTANTLY_JQ_LIB)")
+	rootCmd.PersistentFlags().BoolVarP(&flags.Raw, "raw", "r", false, "Print string results without JSON quotes, one line per --jq output (json, jsonl, agent)")
+	rootCmd.PersistentFlags().StringArrayVar(&flags.Where, "where", nil, "Keep list items matching 'field op value'; op is = != < <= > >= ~ (regex) or in (repeatable, all must match)")
+	rootCmd.PersistentFlags().StringVar(&flags.SortBy, "sort-by", "", "Sort list items by field[:desc]")
+	rootCmd.PersistentFlags().StringVar(&flags.Fields, "fields", "", "Comma-separated fields to select: a.b, a.b:alias, a[].b, a.*, -a (shorthand for --jq)")
+	rootCmd.PersistentFlags().StringVar(&flags.Columns, "columns", "", "Comma-separated columns (dotted paths) for --output text tables and key/value views")
+	rootCmd.PersistentFlags().BoolVar(&flags.NoHeaders, "no-headers", false, "Omit the header row in --output text/csv/tsv tables")
+	rootCmd.PersistentFlags().StringVar(&flags.Template, "template", "", "Render output with a Go template (implies --output template), e.g. '{{range .items}}{{.email}}{{println}}{{end}}'")
+	rootCmd.PersistentFlags().StringVar(&flags.TemplateFile, "template-file", "", "Read the --template body from a file")
+	rootCmd.PersistentFlags().BoolVar(&flags.Envelope, "envelope", false, "Wrap yaml/template output in the agent envelope")
+	rootCmd.PersistentFlags().IntVar(&flags.EnvelopeVersion, "envelope-version", flags.EnvelopeVersion, "Agent envelope version: 2, or 1 for the legacy shape (or set INSTANTLY_ENVELOPE_VERSION)")
+	rootCmd.PersistentFlags().BoolVar(&flags.JSONLMeta, "jsonl-meta", false, "End jsonl list output with a {\"_meta\": ...} record (cursor, pagination, rate limit)")
 
 	rootCmd.PersistentFlags().IntVar(&flags.Max429Retries, "max-429-retries", 0, "Max retries for 429 responses (default 0; safe for GETs)")
 	rootCmd.PersistentFlags().IntVar(&flags.Max5xxRetries, "max-5xx-retries", 0, "Max retries for transient 5xx responses (default 0; safe for GETs)")
 	rootCmd.PersistentFlags().DurationVar(&flags.RetryDelay, "retry-delay", flags.RetryDelay, "Base delay between retries (e.g. 1s)")
 	rootCmd.PersistentFlags().DurationVar(&flags.MaxRetryDelay, "max-retry-delay", flags.MaxRetryDelay, "Max delay between retries")
 	rootCmd.PersistentFlags().StringVar(&flags.IdempotencyKey, "idempotency-key", "", "Idempotency key for write requests (enables safe retries for writes when supported)")
+	rootCmd.PersistentFlags().BoolVar(&flags.AutoIdempotency, "auto-idempotency", false, "Generate an idempotency key per write and reuse it when the identical command is re-run within 24h without having succeeded; meta.idempotency.reused reports reuse")
+	rootCmd.PersistentFlags().StringVar(&flags.IdempotencyJournal, "idempotency-journal", "", "Journal file for --auto-idempotency (default: user cache dir)")
+	rootCmd.PersistentFlags().StringVar(&flags.RateLimitState, "rate-limit-state", flags.RateLimitState, "Share rate-limit pacing across processes via this file ('auto' = user cache dir; or set INSTANTLY_RATE_LIMIT_STATE)")
+	rootCmd.PersistentFlags().BoolVar(&flags.NoRateLimit, "no-rate-limit", flags.NoRateLimit, "Turn off proactive pacing from x-ratelimit-* headers, which the CLI does by default (or set INSTANTLY_NO_RATE_LIMIT=1)")
+
+	rootCmd.PersistentFlags().StringVar(&flags.Record, "record", "", "Record every HTTP request/response to files in this dir (API key and credential headers redacted)")
+	rootCmd.PersistentFlags().StringVar(&flags.Replay, "replay", "", "Serve responses from a --record dir instead of the network")
+
+	rootCmd.PersistentFlags().DurationVar(&flags.CacheTTL, "cache-ttl", 0, "Serve GET responses from an on-disk cache for this long (e.g. 5m; 0 disables)")
+	rootCmd.PersistentFlags().StringVar(&flags.CacheDir, "cache-dir", "", "Response cache directory (default: user cache dir)")
+
+	rootCmd.PersistentFlags().StringArrayVar(&flags.Headers, "header", nil, "Extra request header as key=value (repeatable)")
+	rootCmd.PersistentFlags().StringVar(&flags.CAFile, "ca-file", "", "PEM CA bundle to trust in addition to system roots")
+	rootCmd.PersistentFlags().StringVar(&flags.Proxy, "proxy", "", "HTTP(S) proxy URL (default: HTTPS_PROXY/HTTP_PROXY from the environment)")
+	rootCmd.PersistentFlags().BoolVar(&flags.InsecureSkipVerify, "insecure-skip-verify", false, "Disable TLS certificate verification (testing only)")
 
 	rootCmd.AddCommand(newAccountsCmd())
 	rootCmd.AddCommand(newAccountCampaignMappingsCmd())
@@ -203,4 +535,6 @@ func initRootFlagsAndCommands(rootCmd *cobra.Command) {
 	rootCmd.AddCommand(newDFYEmailAccountOrdersCmd())
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(newSchemaCmd())
+	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newAuthCmd())
 }
@@ -2,7 +2,12 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -63,18 +68,40 @@ func TestClientFromFlags(t *testing.T) {
 	resetFlagsToDefaults()
 	flags.APIKey = ""
 	flags.DryRun = false
-	if _, err := clientFromFlags(); err == nil {
+	if _, err := clientFromFlags(context.Background()); err == nil {
 		t.Fatalf("expected error")
 	}
 
 	resetFlagsToDefaults()
 	flags.APIKey = ""
 	flags.DryRun = true
-	if _, err := clientFromFlags(); err != nil {
+	if _, err := clientFromFlags(context.Background()); err != nil {
 		t.Fatalf("err=%v", err)
 	}
 }
 
+func TestClientFromFlags_RateLimit(t *testing.T) {
+	resetFlagsToDefaults()
+	flags.APIKey = "k"
+	c, err := clientFromFlags(context.Background())
+	if err != nil || c.RateLimiter == nil {
+		t.Fatalf("expected pacing on by default: c=%v err=%v", c, err)
+	}
+
+	t.Setenv("INSTANTLY_NO_RATE_LIMIT", "1")
+	resetFlagsToDefaults()
+	flags.APIKey = "k"
+	c, err = clientFromFlags(context.Background())
+	if err != nil || c.RateLimiter != nil {
+		t.Fatalf("expected INSTANTLY_NO_RATE_LIMIT to turn pacing off: err=%v", err)
+	}
+
+	res := execCLI(t, "--no-rate-limit", "--dry-run", "--json", "jobs", "get", "j1")
+	if res.Err != nil {
+		t.Fatalf("--no-rate-limit: err=%v stdout=%s", res.Err, res.Stdout)
+	}
+}
+
 func TestPersistentPreRunBehavior(t *testing.T) {
 	// --json should force output json.
 	res := execCLI(t, "version", "--json")
@@ -124,3 +151,75 @@ func TestQuietAndSilent(t *testing.T) {
 		t.Fatalf("expected no stdout, got %q", string(res.Stdout))
 	}
 }
+
+func TestDebugTracesToStderr(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"j1"}`))
+	}))
+	defer srv.Close()
+
+	res := execCLI(t, "jobs", "get", "j1", "--api-key", "k-123456789", "--base-url", srv.URL, "--debug", "--debug-format", "json")
+	if res.Err != nil {
+		t.Fatalf("err=%v", res.Err)
+	}
+	ev, ok := mustJSON(t, bytes.TrimSpace(res.Stderr)).(map[string]any)
+	if !ok || ev["method"] != "GET" || ev["status"] != float64(200) {
+		t.Fatalf("stderr=%q", res.Stderr)
+	}
+	if strings.Contains(string(res.Stderr), "k-123456789") {
+		t.Fatalf("api key leaked: %q", res.Stderr)
+	}
+
+	res = execCLI(t, "version", "--debug-format", "xml")
+	if res.Err == nil {
+		t.Fatalf("expected invalid --debug-format error")
+	}
+}
+
+func TestCacheTTL_ReportsCacheInMeta(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"j1"}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	for _, want := range []string{"miss", "hit"} {
+		res := execCLI(t, "jobs", "get", "j1", "--api-key", "k", "--base-url", srv.URL, "--cache-ttl", "1m", "--cache-dir", dir)
+		if res.Err != nil {
+			t.Fatalf("err=%v", res.Err)
+		}
+		env := mustJSON(t, res.Stdout).(map[string]any)
+		if meta, _ := env["meta"].(map[string]any); meta["cache"] != want {
+			t.Fatalf("want cache=%s, got %v", want, env)
+		}
+	}
+}
+
+func TestTransportFlags_TLSServer(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"j1","gateway":"` + r.Header.Get("X-Gateway") + `"}`))
+	}))
+	defer srv.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600); err != nil {
+		t.Fatalf("write ca: %v", err)
+	}
+	base := []string{"jobs", "get", "j1", "--api-key", "k", "--base-url", srv.URL, "--output", "json"}
+
+	if res := execCLI(t, base...); res.Err == nil {
+		t.Fatalf("expected TLS verification error")
+	}
+	for _, extra := range [][]string{{"--ca-file", caFile}, {"--insecure-skip-verify"}} {
+		res := execCLI(t, append(append(base, extra...), "--header", "X-Gateway=egress-1")...)
+		if res.Err != nil {
+			t.Fatalf("%v: err=%v", extra, res.Err)
+		}
+		if out := mustJSON(t, res.Stdout).(map[string]any); out["gateway"] != "egress-1" {
+			t.Fatalf("%v: out=%v", extra, out)
+		}
+	}
+	if res := execCLI(t, append(base, "--header", "bad")...); res.Err == nil {
+		t.Fatalf("expected --header parse error")
+	}
+}
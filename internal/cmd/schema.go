@@ -17,26 +17,30 @@ type flagSchema struct {
 }
 
 type cmdSchema struct {
-	Path         string       `json:"path"`
-	Use          string       `json:"use"`
-	Aliases      []string     `json:"aliases,omitempty"`
-	Short        string       `json:"short,omitempty"`
-	Long         string       `json:"long,omitempty"`
-	Example      string       `json:"example,omitempty"`
-	HTTPMethod   string       `json:"http_method,omitempty"`
-	Endpoint     string       `json:"endpoint,omitempty"`
-	IsWrite      bool         `json:"is_write,omitempty"`
-	HasConfirm   bool         `json:"has_confirm,omitempty"`
-	NeedsConfirm bool         `json:"needs_confirm,omitempty"`
-	PayloadFlags []string     `json:"payload_flags,omitempty"`
-	Flags        []flagSchema `json:"flags,omitempty"`
-	Subcommands  []cmdSchema  `json:"subcommands,omitempty"`
+	Path         string   `json:"path"`
+	Use          string   `json:"use"`
+	Aliases      []string `json:"aliases,omitempty"`
+	Short        string   `json:"short,omitempty"`
+	Long         string   `json:"long,omitempty"`
+	Example      string   `json:"example,omitempty"`
+	HTTPMethod   string   `json:"http_method,omitempty"`
+	Endpoint     string   `json:"endpoint,omitempty"`
+	IsWrite      bool     `json:"is_write,omitempty"`
+	HasConfirm   bool     `json:"has_confirm,omitempty"`
+	NeedsConfirm bool     `json:"needs_confirm,omitempty"`
+	PayloadFlags []string `json:"payload_flags,omitempty"`
+	// RequiredScopes are the API key scopes the command may need.
+	RequiredScopes []string     `json:"required_scopes,omitempty"`
+	Flags          []flagSchema `json:"flags,omitempty"`
+	Subcommands    []cmdSchema  `json:"subcommands,omitempty"`
 }
 
 type rootSchema struct {
 	Name            string       `json:"name"`
 	PersistentFlags []flagSchema `json:"persistent_flags,omitempty"`
 	Commands        []cmdSchema  `json:"commands"`
+	// Errors lists every error code with its exit status.
+	Errors []errorClass `json:"errors"`
 }
 
 func newSchemaCmd() *cobra.Command {
@@ -50,6 +54,7 @@ func newSchemaCmd() *cobra.Command {
 				Name:            root.Name(),
 				PersistentFlags: flagsFromFlagSet(root.PersistentFlags()),
 				Commands:        subcommandsSchema(root),
+				Errors:          errorClasses,
 			}
 			return printResult(cmd, "schema", out, nil)
 		},
@@ -101,6 +106,7 @@ func commandSchema(cmd *cobra.Command) cmdSchema {
 
 	// Payload-related flags: a fast way for an agent to know how to supply input.
 	out.PayloadFlags = payloadFlagsFrom(cmd.Flags())
+	out.RequiredScopes = declaredScopes(cmd)
 
 	return out
 }
@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/internal/api"
+)
+
+// scopeCacheTTL is how long --preflight-scopes trusts a fetched scope list,
+// whatever --cache-ttl the command itself uses.
+const scopeCacheTTL = time.Hour
+
+// commandScopes lists the API key scopes each command needs, keyed by command
+// path without the root. Scopes are resource:action; a key with resource:all,
+// all:action or all:all also satisfies one. Commands not listed (config, auth,
+// schema, raw api) are not checked.
+var commandScopes = map[string][]string{
+	"account-campaign-mappings get": {"account_campaign_mappings:read"},
+
+	"accounts list":            {"accounts:read"},
+	"accounts get":             {"accounts:read"},
+	"accounts analytics-daily": {"accounts:read"},
+	"accounts test-vitals":     {"accounts:read"},
+	"accounts create":          {"accounts:create"},
+	"accounts update":          {"accounts:update"},
+	"accounts warmup-enable":   {"accounts:update"},
+	"accounts warmup-disable":  {"accounts:update"},
+	"accounts delete":          {"accounts:delete"},
+
+	"analytics campaign": {"campaigns:read"},
+	"analytics daily":    {"campaigns:read"},
+	"analytics warmup":   {"accounts:read"},
+
+	"api-keys list":   {"api_keys:read"},
+	"api-keys create": {"api_keys:create"},
+	"api-keys delete": {"api_keys:delete"},
+
+	"audit-logs list": {"audit_logs:read"},
+
+	"block-list-entries list":   {"block_list_entries:read"},
+	"block-list-entries get":    {"block_list_entries:read"},
+	"block-list-entries create": {"block_list_entries:create"},
+	"block-list-entries update": {"block_list_entries:update"},
+	"block-list-entries delete": {"block_list_entries:delete"},
+
+	"campaigns list":               {"campaigns:read"},
+	"campaigns get":                {"campaigns:read"},
+	"campaigns search-by-contact":  {"campaigns:read"},
+	"campaigns analytics-overview": {"campaigns:read"},
+	"campaigns analytics-steps":    {"campaigns:read"},
+	"campaigns create":             {"campaigns:create", "accounts:read"},
+	"campaigns update":             {"campaigns:update"},
+	"campaigns activate":           {"campaigns:update"},
+	"campaigns pause":              {"campaigns:update"},
+	"campaigns delete":             {"campaigns:delete"},
+
+	"crm-actions phone-numbers list":   {"crm_actions:read"},
+	"crm-actions phone-numbers delete": {"crm_actions:delete"},
+
+	"custom-tags list":            {"custom_tags:read"},
+	"custom-tags get":             {"custom_tags:read"},
+	"custom-tags mappings":        {"custom_tags:read"},
+	"custom-tags create":          {"custom_tags:create"},
+	"custom-tags update":          {"custom_tags:update"},
+	"custom-tags toggle-resource": {"custom_tags:update"},
+	"custom-tags delete":          {"custom_tags:delete"},
+
+	"dfy-email-account-orders list":                       {"dfy_email_account_orders:read"},
+	"dfy-email-account-orders accounts list":              {"dfy_email_account_orders:read"},
+	"dfy-email-account-orders domains check":              {"dfy_email_account_orders:read"},
+	"dfy-email-account-orders domains pre-warmed-up-list": {"dfy_email_account_orders:read"},
+	"dfy-email-account-orders domains similar":            {"dfy_email_account_orders:read"},
+	"dfy-email-account-orders create":                     {"dfy_email_account_orders:create"},
+	"dfy-email-account-orders accounts cancel":            {"dfy_email_account_orders:update"},
+
+	"emails list":             {"emails:read"},
+	"emails get":              {"emails:read"},
+	"emails unread-count":     {"emails:read"},
+	"emails reply":            {"emails:create"},
+	"emails forward":          {"emails:create"},
+	"emails update":           {"emails:update"},
+	"emails mark-thread-read": {"emails:update"},
+	"emails delete":           {"emails:delete"},
+	"emails verify":           {"email_verification:create", "email_verification:read"},
+
+	"inbox-placement analytics list":                    {"inbox_placement_analytics:read"},
+	"inbox-placement analytics get":                     {"inbox_placement_analytics:read"},
+	"inbox-placement analytics deliverability-insights": {"inbox_placement_analytics:read"},
+	"inbox-placement analytics stats-by-date":           {"inbox_placement_analytics:read"},
+	"inbox-placement analytics stats-by-test-id":        {"inbox_placement_analytics:read"},
+	"inbox-placement reports list":                      {"inbox_placement_reports:read"},
+	"inbox-placement reports get":                       {"inbox_placement_reports:read"},
+	"inbox-placement tests list":                        {"inbox_placement_tests:read"},
+	"inbox-placement tests get":                         {"inbox_placement_tests:read"},
+	"inbox-placement tests esps":                        {"inbox_placement_tests:read"},
+	"inbox-placement tests create":                      {"inbox_placement_tests:create"},
+	"inbox-placement tests update":                      {"inbox_placement_tests:update"},
+	"inbox-placement tests delete":                      {"inbox_placement_tests:delete"},
+
+	"jobs list": {"background_jobs:read"},
+	"jobs get":  {"background_jobs:read"},
+
+	"lead-labels list":   {"lead_labels:read"},
+	"lead-labels get":    {"lead_labels:read"},
+	"lead-labels create": {"lead_labels:create"},
+	"lead-labels update": {"lead_labels:update"},
+	"lead-labels delete": {"lead_labels:delete"},
+
+	"lead-lists list":               {"lead_lists:read"},
+	"lead-lists get":                {"lead_lists:read"},
+	"lead-lists verification-stats": {"lead_lists:read"},
+	"lead-lists create":             {"lead_lists:create"},
+	"lead-lists update":             {"lead_lists:update"},
+	"lead-lists delete":             {"lead_lists:delete"},
+
+	"leads list":                   {"leads:read"},
+	"leads get":                    {"leads:read"},
+	"leads create":                 {"leads:create"},
+	"leads update":                 {"leads:update"},
+	"leads merge":                  {"leads:update"},
+	"leads update-interest-status": {"leads:update"},
+	"leads delete":                 {"leads:delete"},
+	"leads bulk-delete":            {"leads:delete"},
+
+	"oauth google-init":    {"accounts:create"},
+	"oauth microsoft-init": {"accounts:create"},
+	"oauth session-status": {"accounts:read"},
+
+	"subsequences list":      {"subsequences:read"},
+	"subsequences get":       {"subsequences:read"},
+	"subsequences create":    {"subsequences:create"},
+	"subsequences duplicate": {"subsequences:create"},
+	"subsequences update":    {"subsequences:update"},
+	"subsequences pause":     {"subsequences:update"},
+	"subsequences resume":    {"subsequences:update"},
+	"subsequences delete":    {"subsequences:delete"},
+
+	"supersearch-enrichment get":             {"supersearch_enrichment:read"},
+	"supersearch-enrichment history":         {"supersearch_enrichment:read"},
+	"supersearch-enrichment count-leads":     {"supersearch_enrichment:read"},
+	"supersearch-enrichment create":          {"supersearch_enrichment:create"},
+	"supersearch-enrichment ai":              {"supersearch_enrichment:create"},
+	"supersearch-enrichment enrich-leads":    {"supersearch_enrichment:create"},
+	"supersearch-enrichment run":             {"supersearch_enrichment:create"},
+	"supersearch-enrichment update-settings": {"supersearch_enrichment:update"},
+
+	"webhooks list":                   {"webhooks:read"},
+	"webhooks get":                    {"webhooks:read"},
+	"webhooks event-types":            {"webhooks:read"},
+	"webhooks events list":            {"webhooks:read"},
+	"webhooks events get":             {"webhooks:read"},
+	"webhooks events summary":         {"webhooks:read"},
+	"webhooks events summary-by-date": {"webhooks:read"},
+	"webhooks create":                 {"webhooks:create"},
+	"webhooks update":                 {"webhooks:update"},
+	"webhooks resume":                 {"webhooks:update"},
+	"webhooks test":                   {"webhooks:update"},
+	"webhooks delete":                 {"webhooks:delete"},
+
+	"workspace-billing plan-details":         {"workspace_billing:read"},
+	"workspace-billing subscription-details": {"workspace_billing:read"},
+
+	"workspace-group-members list":   {"workspace_group_members:read"},
+	"workspace-group-members get":    {"workspace_group_members:read"},
+	"workspace-group-members admin":  {"workspace_group_members:read"},
+	"workspace-group-members create": {"workspace_group_members:create"},
+	"workspace-group-members delete": {"workspace_group_members:delete"},
+
+	"workspace-members list":   {"workspace_members:read"},
+	"workspace-members get":    {"workspace_members:read"},
+	"workspace-members create": {"workspace_members:create"},
+	"workspace-members update": {"workspace_members:update"},
+	"workspace-members delete": {"workspace_members:delete"},
+
+	"workspaces current get":              {"workspaces:read"},
+	"workspaces whitelabel-domain get":    {"workspaces:read"},
+	"workspaces create":                   {"workspaces:create"},
+	"workspaces current update":           {"workspaces:update"},
+	"workspaces change-owner":             {"workspaces:update"},
+	"workspaces whitelabel-domain set":    {"workspaces:update"},
+	"workspaces whitelabel-domain delete": {"workspaces:update"},
+}
+
+// conditionalScopes are declared scopes a command only needs when a flag has
+// a given value, e.g. campaigns create lists accounts only for --senders auto.
+var conditionalScopes = map[string]struct{ Scope, Flag, Value string }{
+	"campaigns create": {"accounts:read", "senders", "auto"},
+}
+
+func scopeKey(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// declaredScopes returns every scope cmd may need, for schema output.
+func declaredScopes(cmd *cobra.Command) []string {
+	return commandScopes[scopeKey(cmd)]
+}
+
+// neededScopes returns the scopes this invocation of cmd needs.
+func neededScopes(cmd *cobra.Command) []string {
+	key := scopeKey(cmd)
+	scopes := commandScopes[key]
+	if c, ok := conditionalScopes[key]; ok {
+		if f := cmd.Flags().Lookup(c.Flag); f != nil && !strings.EqualFold(strings.TrimSpace(f.Value.String()), c.Value) {
+			scopes = slices.DeleteFunc(slices.Clone(scopes), func(s string) bool { return s == c.Scope })
+		}
+	}
+	return scopes
+}
+
+// scopeGranted reports whether have covers need, honoring :all and all: wildcards.
+func scopeGranted(have []string, need string) bool {
+	resource, action, _ := strings.Cut(need, ":")
+	for _, h := range have {
+		r, a, _ := strings.Cut(h, ":")
+		if (r == resource || r == "all") && (a == action || a == "all") {
+			return true
+		}
+	}
+	return false
+}
+
+// missingScopeError lists the scopes the API key must be granted.
+type missingScopeError struct {
+	Missing []string
+}
+
+func (e *missingScopeError) Error() string {
+	return "API key is missing scope(s) " + strings.Join(e.Missing, ", ") + "; add them to the key in Instantly settings, or use a key that has them"
+}
+
+func defaultPreflightScopes() bool {
+	v, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv("INSTANTLY_PREFLIGHT_SCOPES")))
+	return v
+}
+
+// preflightScopes checks, before the command sends anything, that the API key
+// has the scopes cmd needs. The key's scopes come from /api-keys through the
+// response cache, so repeated commands cost one lookup per hour; a cached list
+// that lacks a scope is refetched before refusing, in case the scope was added
+// since. When the scopes cannot be read (the key may not list keys, or several
+// masked keys match it) it warns and lets the command run.
+func preflightScopes(cmd *cobra.Command) error {
+	if !flags.PreflightScopes || flags.DryRun || flags.Replay != "" || len(flags.Profiles) > 0 || flags.AllProfiles {
+		return nil
+	}
+	need := neededScopes(cmd)
+	if len(need) == 0 {
+		return nil
+	}
+	have, cached, err := keyScopes(cmd, false)
+	if err == nil && cached && len(missingScopes(have, need)) > 0 {
+		have, _, err = keyScopes(cmd, true)
+	}
+	if err != nil {
+		switch classifyError(err).Code {
+		case codeAuth, codeCancelled, codeTimeout:
+			// The command itself would fail the same way.
+			return err
+		}
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: scope preflight skipped: %v\n", err)
+		return nil
+	}
+	if missing := missingScopes(have, need); len(missing) > 0 {
+		return &codedError{code: codeMissingScope, err: &missingScopeError{Missing: missing}}
+	}
+	return nil
+}
+
+func missingScopes(have, need []string) []string {
+	var missing []string
+	for _, s := range need {
+		if !scopeGranted(have, s) {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+// keyScopes returns the scopes of the API key in use and whether they came from
+// the cache. fresh skips the cache (and refreshes it).
+func keyScopes(cmd *cobra.Command, fresh bool) (scopes []string, cached bool, err error) {
+	// The lookup is not part of the command, so keep it out of its cassette.
+	record := flags.Record
+	flags.Record = ""
+	c, err := clientFromFlags(cmdContext(cmd))
+	flags.Record = record
+	if err != nil {
+		return nil, false, err
+	}
+	dir := flags.CacheDir
+	if dir == "" {
+		if dir, err = api.DefaultCacheDir(); err != nil {
+			return nil, false, err
+		}
+	}
+	c.Cache = api.NewCache(dir, c.APIKey, scopeCacheTTL)
+	if fresh {
+		// Every entry reads as stale, so the list is fetched and re-cached.
+		c.Cache.TTL = 0
+	}
+	resp, meta, err := c.GetJSON(cmdContext(cmd), "/api-keys", nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("list API keys: %w", err)
+	}
+	k, ambiguous := matchAPIKey(resp, c.APIKey)
+	switch {
+	case ambiguous:
+		return nil, false, errors.New("several masked keys in /api-keys end like the API key in use")
+	case k == nil:
+		return nil, false, errors.New("the API key in use is not in /api-keys")
+	}
+	raw, _ := k["scopes"].([]any)
+	scopes = make([]string, 0, len(raw))
+	for _, s := range raw {
+		if s, ok := s.(string); ok {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes, meta != nil && meta.Cache == api.CacheHit, nil
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCommandScopes_CoverEveryAPICommand(t *testing.T) {
+	root := newRootCmd()
+	exempt := []string{"api", "auth", "config", "schema", "version", "help", "completion"}
+	seen := map[string]bool{}
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sc := range c.Commands() {
+			walk(sc)
+		}
+		if c == root || !c.Runnable() {
+			return
+		}
+		key := scopeKey(c)
+		seen[key] = true
+		top, _, _ := strings.Cut(key, " ")
+		if !slices.Contains(exempt, top) && len(commandScopes[key]) == 0 {
+			t.Errorf("%s declares no scopes", key)
+		}
+	}
+	walk(root)
+	for key := range commandScopes {
+		if !seen[key] {
+			t.Errorf("commandScopes has %q, which is not a command", key)
+		}
+	}
+}
+
+func TestScopeGranted(t *testing.T) {
+	for _, tc := range []struct {
+		have []string
+		need string
+		want bool
+	}{
+		{[]string{"campaigns:read"}, "campaigns:read", true},
+		{[]string{"campaigns:read"}, "campaigns:update", false},
+		{[]string{"campaigns:all"}, "campaigns:delete", true},
+		{[]string{"all:read"}, "leads:read", true},
+		{[]string{"all:read"}, "leads:create", false},
+		{[]string{"all:all"}, "webhooks:delete", true},
+		{nil, "leads:read", false},
+	} {
+		if got := scopeGranted(tc.have, tc.need); got != tc.want {
+			t.Errorf("scopeGranted(%v, %q) = %v", tc.have, tc.need, got)
+		}
+	}
+}
+
+func TestPreflightScopes(t *testing.T) {
+	var keyLists, campaignCalls atomic.Int32
+	var forbidKeys, canCreate atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api-keys":
+			keyLists.Add(1)
+			if forbidKeys.Load() {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"message":"Forbidden"}`))
+				return
+			}
+			scopes := `"campaigns:read","all:read"`
+			if canCreate.Load() {
+				scopes += `,"campaigns:create"`
+			}
+			_, _ = w.Write([]byte(`{"items":[{"id":"k1","name":"ro","key":"****-key","scopes":[` + scopes + `]},{"id":"k2","key":"****7890","scopes":[]},{"id":"k3","key":"****7890","scopes":[]}]}`))
+		default:
+			campaignCalls.Add(1)
+			_, _ = w.Write([]byte(`{"items":[]}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	cacheDir := t.TempDir()
+	run := func(args ...string) execResult {
+		return execCLI(t, append([]string{"--json", "--preflight-scopes", "--api-key", "sk-read-key", "--base-url", srv.URL, "--cache-dir", cacheDir}, args...)...)
+	}
+
+	// Explicit senders: only campaigns:create is needed, and nothing is sent.
+	res := run("campaigns", "create", "--name", "n", "--subject", "s", "--body", "b", "--senders", "a@x.com")
+	if ExitCode(res.Err) != 12 || campaignCalls.Load() != 0 {
+		t.Fatalf("err=%v calls=%d stdout=%s", res.Err, campaignCalls.Load(), res.Stdout)
+	}
+	var scopeErr *missingScopeError
+	if !errors.As(res.Err, &scopeErr) || !slices.Equal(scopeErr.Missing, []string{"campaigns:create"}) {
+		t.Fatalf("err=%#v", res.Err)
+	}
+	var buf bytes.Buffer
+	root := newRootCmd()
+	root.SetOut(&buf)
+	reportUncaught(root, res.Err)
+	if out := mustJSON(t, buf.Bytes()).(map[string]any); out["code"] != "missing_scope" || out["missing_scopes"] == nil {
+		t.Fatalf("out=%v", out)
+	}
+
+	// all:read covers accounts:read; the scope list comes from the cache.
+	if res := run("accounts", "list"); res.Err != nil || campaignCalls.Load() != 1 {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+	if keyLists.Load() != 1 {
+		t.Fatalf("api-keys fetched %d times, want 1 (cached)", keyLists.Load())
+	}
+
+	// The scope cache keeps its own TTL under a short --cache-ttl, and the
+	// lookup stays out of --record cassettes.
+	recDir := t.TempDir()
+	if res := run("--cache-ttl", "1ms", "--record", recDir, "accounts", "list"); res.Err != nil || keyLists.Load() != 1 {
+		t.Fatalf("err=%v keyLists=%d stdout=%s", res.Err, keyLists.Load(), res.Stdout)
+	}
+	if files, _ := filepath.Glob(filepath.Join(recDir, "*.json")); len(files) != 1 || filepath.Base(files[0]) != "0001-get-accounts.json" {
+		t.Fatalf("cassette=%v", files)
+	}
+
+	// A scope added after the list was cached is picked up by a live refetch.
+	canCreate.Store(true)
+	res = run("campaigns", "create", "--name", "n", "--subject", "s", "--body", "b", "--senders", "a@x.com")
+	if res.Err != nil || campaignCalls.Load() != 3 || keyLists.Load() != 2 {
+		t.Fatalf("err=%v calls=%d keyLists=%d stdout=%s", res.Err, campaignCalls.Load(), keyLists.Load(), res.Stdout)
+	}
+
+	// Several masked keys with the same suffix: skip with a warning rather than guess.
+	res = execCLI(t, "--json", "--preflight-scopes", "--api-key", "sk-ambiguous-7890", "--base-url", srv.URL, "--cache-dir", cacheDir, "campaigns", "list")
+	if res.Err != nil || !strings.Contains(string(res.Stderr), "several masked keys") {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+
+	// A key that cannot list keys skips the check with a warning.
+	forbidKeys.Store(true)
+	res = execCLI(t, "--json", "--preflight-scopes", "--api-key", "sk-other", "--base-url", srv.URL, "--cache-dir", cacheDir, "campaigns", "list")
+	if res.Err != nil || !strings.Contains(string(res.Stderr), "scope preflight skipped") {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+}
+
+func TestSchemaListsRequiredScopes(t *testing.T) {
+	res := execCLI(t, "schema", "--json", "--jq", `.. | objects | select(.path? == "instantly campaigns create") | .required_scopes`)
+	if res.Err != nil || !strings.Contains(string(res.Stdout), `"campaigns:create"`) {
+		t.Fatalf("err=%v stdout=%s", res.Err, res.Stdout)
+	}
+}
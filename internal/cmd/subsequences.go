@@ -32,6 +32,7 @@ func newSubsequencesListCmd() *cobra.Command {
 		parentCampaign string
 		limit          int
 		startingAfter  string
+		pages          pageFlags
 		search         string
 		queryPairs     []string
 	)
@@ -39,12 +40,12 @@ func newSubsequencesListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List subsequences (GET /subsequences, requires --parent-campaign)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "subsequences.list", err, nil)
 			}
 			if strings.TrimSpace(parentCampaign) == "" {
-				return printError(cmd, "subsequences.list", fmt.Errorf("--parent-campaign is required"), nil)
+				return printError(cmd, "subsequences.list", usageErrorf("--parent-campaign is required"), nil)
 			}
 			q := url.Values{}
 			q.Set("parent_campaign", strings.TrimSpace(parentCampaign))
@@ -61,16 +62,13 @@ func newSubsequencesListCmd() *cobra.Command {
 				return printError(cmd, "subsequences.list", err, nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/subsequences", q)
-			if err != nil {
-				return printError(cmd, "subsequences.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "subsequences.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "subsequences.list", "/subsequences", q, pages)
 		},
 	}
 	cmd.Flags().StringVar(&parentCampaign, "parent-campaign", "", "Parent campaign ID (UUID)")
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringVar(&search, "search", "", "Search")
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
@@ -82,13 +80,13 @@ func newSubsequencesGetCmd() *cobra.Command {
 		Short: "Get subsequence (GET /subsequences/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "subsequences.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "subsequences.get", fmt.Errorf("subsequence_id is required"), nil)
+				return printError(cmd, "subsequences.get", usageErrorf("subsequence_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/subsequences/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -109,7 +107,7 @@ func newSubsequencesCreateCmd() *cobra.Command {
 		Use:   "create",
 		Short: "Create subsequence (POST /subsequences)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "subsequences.create", err, nil)
 			}
@@ -118,7 +116,7 @@ func newSubsequencesCreateCmd() *cobra.Command {
 				return printError(cmd, "subsequences.create", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "subsequences.create", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "subsequences.create", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/subsequences", nil, body)
 			if err != nil {
@@ -142,20 +140,20 @@ func newSubsequencesUpdateCmd() *cobra.Command {
 		Short: "Update subsequence (PATCH /subsequences/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "subsequences.update", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "subsequences.update", fmt.Errorf("subsequence_id is required"), nil)
+				return printError(cmd, "subsequences.update", usageErrorf("subsequence_id is required"), nil)
 			}
 			body, err := readJSONObjectInput(dataJSON, dataFile)
 			if err != nil {
 				return printError(cmd, "subsequences.update", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "subsequences.update", fmt.Errorf("provide --data-json or --data-file with at least one field"), nil)
+				return printError(cmd, "subsequences.update", usageErrorf("provide --data-json or --data-file with at least one field"), nil)
 			}
 			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/subsequences/"+url.PathEscape(id), nil, body)
 			if err != nil {
@@ -177,15 +175,15 @@ func newSubsequencesDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "subsequences.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "subsequences.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "subsequences.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "subsequences.delete", fmt.Errorf("subsequence_id is required"), nil)
+				return printError(cmd, "subsequences.delete", usageErrorf("subsequence_id is required"), nil)
 			}
 			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/subsequences/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -210,15 +208,15 @@ func newSubsequencesPauseCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "subsequences.pause", fmt.Errorf("refusing to pause without --confirm"), nil)
+				return printError(cmd, "subsequences.pause", errConfirmRequired("refusing to pause without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "subsequences.pause", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "subsequences.pause", fmt.Errorf("subsequence_id is required"), nil)
+				return printError(cmd, "subsequences.pause", usageErrorf("subsequence_id is required"), nil)
 			}
 			body, err := readJSONObjectInput(dataJSON, dataFile)
 			if err != nil {
@@ -249,15 +247,15 @@ func newSubsequencesResumeCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "subsequences.resume", fmt.Errorf("refusing to resume without --confirm"), nil)
+				return printError(cmd, "subsequences.resume", errConfirmRequired("refusing to resume without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "subsequences.resume", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "subsequences.resume", fmt.Errorf("subsequence_id is required"), nil)
+				return printError(cmd, "subsequences.resume", usageErrorf("subsequence_id is required"), nil)
 			}
 			body, err := readJSONObjectInput(dataJSON, dataFile)
 			if err != nil {
@@ -288,15 +286,15 @@ func newSubsequencesDuplicateCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "subsequences.duplicate", fmt.Errorf("refusing to duplicate without --confirm"), nil)
+				return printError(cmd, "subsequences.duplicate", errConfirmRequired("refusing to duplicate without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "subsequences.duplicate", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "subsequences.duplicate", fmt.Errorf("subsequence_id is required"), nil)
+				return printError(cmd, "subsequences.duplicate", usageErrorf("subsequence_id is required"), nil)
 			}
 			body, err := readJSONObjectInput(dataJSON, dataFile)
 			if err != nil {
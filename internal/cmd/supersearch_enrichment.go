@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"net/url"
 	"strings"
 
@@ -43,13 +42,13 @@ func newSupersearchEnrichmentGetCmd() *cobra.Command {
 		Short: "Get supersearch enrichment resource (GET /supersearch-enrichment/{resource_id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "supersearch_enrichment.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "supersearch_enrichment.get", fmt.Errorf("resource_id is required"), nil)
+				return printError(cmd, "supersearch_enrichment.get", usageErrorf("resource_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/supersearch-enrichment/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -67,13 +66,13 @@ func newSupersearchEnrichmentHistoryCmd() *cobra.Command {
 		Short: "Get supersearch enrichment history (GET /supersearch-enrichment/history/{resource_id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "supersearch_enrichment.history", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "supersearch_enrichment.history", fmt.Errorf("resource_id is required"), nil)
+				return printError(cmd, "supersearch_enrichment.history", usageErrorf("resource_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/supersearch-enrichment/history/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -97,22 +96,22 @@ func newSupersearchEnrichmentUpdateSettingsCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "supersearch_enrichment.update_settings", fmt.Errorf("refusing to update without --confirm"), nil)
+				return printError(cmd, "supersearch_enrichment.update_settings", errConfirmRequired("refusing to update without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "supersearch_enrichment.update_settings", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "supersearch_enrichment.update_settings", fmt.Errorf("resource_id is required"), nil)
+				return printError(cmd, "supersearch_enrichment.update_settings", usageErrorf("resource_id is required"), nil)
 			}
 			body, err := readJSONObjectInput(dataJSON, dataFile)
 			if err != nil {
 				return printError(cmd, "supersearch_enrichment.update_settings", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "supersearch_enrichment.update_settings", fmt.Errorf("provide --data-json or --data-file with at least one field"), nil)
+				return printError(cmd, "supersearch_enrichment.update_settings", usageErrorf("provide --data-json or --data-file with at least one field"), nil)
 			}
 			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/supersearch-enrichment/"+url.PathEscape(id)+"/settings", nil, body)
 			if err != nil {
@@ -175,9 +174,9 @@ func supersearchPostCmd(use, op, short, endpoint string) *cobra.Command {
 		Short: short,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, op, fmt.Errorf("refusing to run without --confirm"), nil)
+				return printError(cmd, op, errConfirmRequired("refusing to run without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, op, err, nil)
 			}
@@ -188,7 +187,7 @@ func supersearchPostCmd(use, op, short, endpoint string) *cobra.Command {
 			if len(body) == 0 {
 				// Allow --resource-id alone to form the minimum body for these endpoints.
 				if strings.TrimSpace(resourceID) == "" {
-					return printError(cmd, op, fmt.Errorf("provide --data-json or --data-file"), nil)
+					return printError(cmd, op, usageErrorf("provide --data-json or --data-file"), nil)
 				}
 				body = map[string]any{"resource_id": strings.TrimSpace(resourceID)}
 			}
@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/instantly"
+	"github.com/salmonumbrella/instantly-cli/internal/api"
 )
 
 func newTagsCmd() *cobra.Command {
@@ -31,6 +35,7 @@ func newTagsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		search        string
 		queryPairs    []string
 	)
@@ -38,32 +43,30 @@ func newTagsListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List custom tags (GET /custom-tags)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "custom_tags.list", err, nil)
 			}
-			q := url.Values{}
-			if limit > 0 {
-				q.Set("limit", fmt.Sprint(limit))
-			}
-			if strings.TrimSpace(startingAfter) != "" {
-				q.Set("starting_after", strings.TrimSpace(startingAfter))
-			}
-			if strings.TrimSpace(search) != "" {
-				q.Set("search", strings.TrimSpace(search))
-			}
-			if err := applyQueryPairs(q, queryPairs); err != nil {
+			params := instantly.ListTagsParams{ListParams: instantly.ListParams{
+				Limit:         limit,
+				StartingAfter: strings.TrimSpace(startingAfter),
+				Search:        strings.TrimSpace(search),
+				Query:         url.Values{},
+			}}
+			if err := applyQueryPairs(params.Query, queryPairs); err != nil {
 				return printError(cmd, "custom_tags.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/custom-tags", q)
-			if err != nil {
-				return printError(cmd, "custom_tags.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "custom_tags.list", resp, metaFrom(meta, resp))
+			return printList(cmd, "custom_tags.list", pages, func(ctx context.Context, cursor string) (any, *api.Meta, error) {
+				if cursor != "" {
+					params.StartingAfter = cursor
+				}
+				return sdkPage(sdk.Tags.List(ctx, params))
+			})
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringVar(&search, "search", "", "Search")
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
@@ -75,19 +78,19 @@ func newTagsGetCmd() *cobra.Command {
 		Short: "Get custom tag (GET /custom-tags/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "custom_tags.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "custom_tags.get", fmt.Errorf("tag_id is required"), nil)
+				return printError(cmd, "custom_tags.get", usageErrorf("tag_id is required"), nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/custom-tags/"+url.PathEscape(id), nil)
+			tag, meta, err := sdk.Tags.Get(cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "custom_tags.get", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "custom_tags.get", resp, metaFrom(meta, resp))
+			return printResult(cmd, "custom_tags.get", tag.Raw, metaFrom(meta, tag.Raw))
 		},
 	}
 	return cmd
@@ -104,7 +107,7 @@ func newTagsCreateCmd() *cobra.Command {
 		Use:   "create",
 		Short: "Create custom tag (POST /custom-tags)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "custom_tags.create", err, nil)
 			}
@@ -125,7 +128,7 @@ func newTagsCreateCmd() *cobra.Command {
 			}
 			labelVal, ok := body["label"].(string)
 			if !ok || strings.TrimSpace(labelVal) == "" {
-				return printError(cmd, "custom_tags.create", fmt.Errorf("--name is required (or set in --data-json/--data-file)"), nil)
+				return printError(cmd, "custom_tags.create", usageErrorf("--name is required (or set in --data-json/--data-file)"), nil)
 			}
 
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/custom-tags", nil, body)
@@ -154,13 +157,13 @@ func newTagsUpdateCmd() *cobra.Command {
 		Short: "Update custom tag (PATCH /custom-tags/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "custom_tags.update", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "custom_tags.update", fmt.Errorf("tag_id is required"), nil)
+				return printError(cmd, "custom_tags.update", usageErrorf("tag_id is required"), nil)
 			}
 
 			body, err := readJSONObjectInput(dataJSON, dataFile)
@@ -179,7 +182,7 @@ func newTagsUpdateCmd() *cobra.Command {
 				body["color"] = strings.TrimSpace(color)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "custom_tags.update", fmt.Errorf("no fields to update"), nil)
+				return printError(cmd, "custom_tags.update", usageErrorf("no fields to update"), nil)
 			}
 
 			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/custom-tags/"+url.PathEscape(id), nil, body)
@@ -204,15 +207,15 @@ func newTagsDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "custom_tags.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "custom_tags.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "custom_tags.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "custom_tags.delete", fmt.Errorf("tag_id is required"), nil)
+				return printError(cmd, "custom_tags.delete", usageErrorf("tag_id is required"), nil)
 			}
 			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/custom-tags/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -244,7 +247,7 @@ func newTagsToggleResourceCmd() *cobra.Command {
 			}
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "custom_tags.toggle_resource", err, nil)
 			}
@@ -267,15 +270,15 @@ func newTagsToggleResourceCmd() *cobra.Command {
 
 			tagIDVal, ok := body["custom_tag_id"].(string)
 			if !ok || strings.TrimSpace(tagIDVal) == "" {
-				return printError(cmd, "custom_tags.toggle_resource", fmt.Errorf("--tag-id is required (or set custom_tag_id in body)"), nil)
+				return printError(cmd, "custom_tags.toggle_resource", usageErrorf("--tag-id is required (or set custom_tag_id in body)"), nil)
 			}
 			resourceIDVal, ok := body["resource_id"].(string)
 			if !ok || strings.TrimSpace(resourceIDVal) == "" {
-				return printError(cmd, "custom_tags.toggle_resource", fmt.Errorf("--resource-id is required (or set resource_id in body)"), nil)
+				return printError(cmd, "custom_tags.toggle_resource", usageErrorf("--resource-id is required (or set resource_id in body)"), nil)
 			}
 			resourceTypeVal, ok := body["resource_type"].(string)
 			if !ok || strings.TrimSpace(resourceTypeVal) == "" {
-				return printError(cmd, "custom_tags.toggle_resource", fmt.Errorf("--resource-type is required (or set resource_type in body)"), nil)
+				return printError(cmd, "custom_tags.toggle_resource", usageErrorf("--resource-type is required (or set resource_type in body)"), nil)
 			}
 
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/custom-tags/toggle-resource", nil, body)
@@ -298,13 +301,14 @@ func newTagMappingsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "mappings",
 		Short: "List custom tag mappings (GET /custom-tag-mappings)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "custom_tag_mappings.list", err, nil)
 			}
@@ -318,15 +322,12 @@ func newTagMappingsListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "custom_tag_mappings.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/custom-tag-mappings", q)
-			if err != nil {
-				return printError(cmd, "custom_tag_mappings.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "custom_tag_mappings.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "custom_tag_mappings.list", "/custom-tag-mappings", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/salmonumbrella/instantly-cli/internal/outfmt"
+)
+
+// outputTemplate is the parsed --template/--template-file for --output template.
+var outputTemplate *template.Template
+
+// loadOutputTemplate parses --template or --template-file.
+func loadOutputTemplate() (*template.Template, error) {
+	body := flags.Template
+	if flags.TemplateFile != "" {
+		b, err := os.ReadFile(flags.TemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("read --template-file: %w", err)
+		}
+		body = string(b)
+	}
+	if body == "" {
+		return nil, usageErrorf("--output template requires --template or --template-file")
+	}
+	t, err := outfmt.ParseTemplate(body)
+	if err != nil {
+		return nil, usageErrorf("invalid template: %w", err)
+	}
+	return t, nil
+}
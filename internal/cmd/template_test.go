@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const accountsBody = `{"items":[{"email":"a@x.com","status":1},{"email":"b@x.com","status":2}]}`
+
+func TestYAMLOutput_RawAndEnvelope(t *testing.T) {
+	srv := jsonServer(t, accountsBody)
+
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "yaml", "accounts", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+	want := "items:\n  - email: a@x.com\n    status: 1\n  - email: b@x.com\n    status: 2\n"
+	if string(res.Stdout) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", res.Stdout, want)
+	}
+
+	res = execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "yaml", "--envelope", "accounts", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+	if !strings.HasPrefix(string(res.Stdout), "items:") || !strings.Contains(string(res.Stdout), "kind: accounts.list\n") {
+		t.Fatalf("expected envelope, got:\n%s", res.Stdout)
+	}
+}
+
+func TestTemplateOutput(t *testing.T) {
+	srv := jsonServer(t, accountsBody)
+
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--template", `{{range .items}}{{.email}} {{.status}}{{"\n"}}{{end}}`, "accounts", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+	if string(res.Stdout) != "a@x.com 1\nb@x.com 2\n" {
+		t.Fatalf("got %q", res.Stdout)
+	}
+
+	file := filepath.Join(t.TempDir(), "t.tmpl")
+	if err := os.WriteFile(file, []byte(`{{.kind}}: {{len .items}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	res = execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--template-file", file, "--envelope", "--jq", ".", "accounts", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+	if string(res.Stdout) != "accounts.list: 2" {
+		t.Fatalf("got %q", res.Stdout)
+	}
+}
+
+func TestTemplateOutput_Errors(t *testing.T) {
+	res := execCLI(t, "--api-key", "k", "--output", "template", "accounts", "list")
+	if res.Err == nil || !strings.Contains(res.Err.Error(), "requires --template") {
+		t.Fatalf("err=%v", res.Err)
+	}
+	res = execCLI(t, "--api-key", "k", "--output", "json", "--template", "{{.}}", "accounts", "list")
+	if res.Err == nil || !strings.Contains(res.Err.Error(), "conflicts") {
+		t.Fatalf("err=%v", res.Err)
+	}
+	res = execCLI(t, "--api-key", "k", "--template", "{{.x", "accounts", "list")
+	if res.Err == nil || !strings.Contains(res.Err.Error(), "invalid template") {
+		t.Fatalf("err=%v", res.Err)
+	}
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/internal/outfmt"
+)
+
+// textColumns are the default table columns (dotted paths into each item) per kind.
+// Kinds without an entry get columns inferred from the first item.
+var textColumns = map[string][]string{
+	"accounts.list":     {"email", "status", "warmup_status", "provider_code"},
+	"api_keys.list":     {"id", "name", "scopes", "timestamp_created"},
+	"campaigns.list":    {"id", "name", "status", "daily_limit"},
+	"custom_tags.list":  {"id", "label", "description"},
+	"emails.list":       {"from_address_email", "subject", "timestamp_email", "is_unread"},
+	"jobs.list":         {"id", "type", "status", "progress"},
+	"lead_lists.list":   {"id", "name", "timestamp_created"},
+	"leads.list":        {"id", "email", "first_name", "last_name", "company_name", "status"},
+	"subsequences.list": {"id", "name", "status"},
+	"webhooks.list":     {"id", "event_type", "target_hook_url"},
+}
+
+// maxInferredColumns caps how many columns are guessed for kinds without defaults.
+const maxInferredColumns = 6
+
+// printText renders resp for people at a terminal: lists as tables, objects as
+// key/value blocks.
+func printText(cmd *cobra.Command, kind string, resp any, meta map[string]any) error {
+	w := cmd.OutOrStdout()
+	width := outfmt.TerminalWidth(w)
+	columns := splitColumns(flags.Columns)
+
+	items, isList := textItems(resp)
+	switch {
+	case isList:
+		if len(columns) == 0 {
+			columns = textColumns[kind]
+		}
+		if len(columns) == 0 {
+			columns = inferColumns(items)
+		}
+		headers := make([]string, len(columns))
+		for i, c := range columns {
+			headers[i] = strings.ToUpper(c)
+		}
+		rows := make([][]string, 0, len(items))
+		for _, it := range items {
+			row := make([]string, len(columns))
+			for i, c := range columns {
+				v, _ := lookupPath(it, c)
+				row[i] = formatTextValue(v)
+			}
+			rows = append(rows, row)
+		}
+		if err := outfmt.PrintTable(w, headers, rows, outfmt.TableOptions{Width: width, NoHeaders: flags.NoHeaders}); err != nil {
+			return err
+		}
+		if next := nextCursor(resp, meta); next != "" {
+			// Hint on stderr so stdout stays a clean table.
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "more results: --starting-after %s\n", next)
+		}
+		return nil
+	default:
+		m, ok := resp.(map[string]any)
+		if !ok {
+			_, err := fmt.Fprintln(w, formatTextValue(resp))
+			return err
+		}
+		keys := columns
+		if len(keys) == 0 {
+			keys = sortedKeys(m)
+		}
+		values := make([]string, len(keys))
+		for i, k := range keys {
+			v, _ := lookupPath(m, k)
+			values[i] = formatTextValue(v)
+		}
+		return outfmt.PrintKV(w, keys, values, width)
+	}
+}
+
+// textItems returns the list in resp (an "items" array or a bare array).
+func textItems(resp any) ([]any, bool) {
+	switch v := resp.(type) {
+	case []any:
+		return v, true
+	case map[string]any:
+		items, ok := v["items"].([]any)
+		return items, ok
+	}
+	return nil, false
+}
+
+// nextCursor returns the cursor for the next page, from --all pagination meta or the raw response.
+func nextCursor(resp any, meta map[string]any) string {
+	if p, ok := meta["pagination"].(map[string]any); ok {
+		next, _ := p["next_starting_after"].(string)
+		return next
+	}
+	if m, ok := resp.(map[string]any); ok {
+		next, _ := m["next_starting_after"].(string)
+		return next
+	}
+	return ""
+}
+
+func splitColumns(s string) []string {
+	var out []string
+	for _, c := range strings.Split(s, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// inferColumns picks the scalar fields of the first object, identifying fields first.
+func inferColumns(items []any) []string {
+	var first map[string]any
+	for _, it := range items {
+		if m, ok := it.(map[string]any); ok {
+			first = m
+			break
+		}
+	}
+	if first == nil {
+		return []string{"value"}
+	}
+	var cols []string
+	for _, k := range []string{"id", "name", "email"} {
+		if isScalar(first[k]) && first[k] != nil {
+			cols = append(cols, k)
+		}
+	}
+	for _, k := range sortedKeys(first) {
+		if len(cols) >= maxInferredColumns {
+			break
+		}
+		if k == "id" || k == "name" || k == "email" || !isScalar(first[k]) {
+			continue
+		}
+		cols = append(cols, k)
+	}
+	return cols
+}
+
+// lookupPath resolves a dotted path ("a.b.c") in v. "value" on a non-object
+// returns v itself so lists of scalars still render.
+func lookupPath(v any, path string) (any, bool) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return v, path == "value"
+	}
+	cur := any(m)
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = obj[part]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func isScalar(v any) bool {
+	switch v.(type) {
+	case map[string]any, []any:
+		return false
+	}
+	return true
+}
+
+func formatTextValue(v any) string {
+	switch vv := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.ReplaceAll(vv, "\n", " ")
+	case json.Number:
+		return vv.String()
+	case bool:
+		return strconv.FormatBool(vv)
+	case float64:
+		return strconv.FormatFloat(vv, 'f', -1, 64)
+	case []any:
+		parts := make([]string, 0, len(vv))
+		for _, it := range vv {
+			if !isScalar(it) {
+				return compactJSON(v)
+			}
+			parts = append(parts, formatTextValue(it))
+		}
+		return strings.Join(parts, ", ")
+	case map[string]any:
+		return compactJSON(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func compactJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextOutput_CampaignsTable(t *testing.T) {
+	srv := jsonServer(t, `{"items":[{"id":"c1","name":"Launch","status":1,"daily_limit":50,"extra":"x"}],"next_starting_after":"c1"}`)
+
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "text", "campaigns", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+	want := "ID  NAME    STATUS  DAILY_LIMIT\n" +
+		"c1  Launch  1       50\n"
+	if string(res.Stdout) != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", res.Stdout, want)
+	}
+	if !strings.Contains(string(res.Stderr), "--starting-after c1") {
+		t.Fatalf("expected next page hint, stderr=%q", res.Stderr)
+	}
+}
+
+func TestTextOutput_ColumnsAndNoHeaders(t *testing.T) {
+	srv := jsonServer(t, `{"items":[{"id":"c1","name":"Launch","settings":{"owner":"ann"}}]}`)
+
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "text", "--columns", "name,settings.owner", "--no-headers", "campaigns", "list")
+	if res.Err != nil {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+	if string(res.Stdout) != "Launch  ann\n" {
+		t.Fatalf("got %q", res.Stdout)
+	}
+}
+
+func TestTextOutput_ItemKeyValue(t *testing.T) {
+	srv := jsonServer(t, `{"id":"c1","name":"Launch","tags":["a","b"],"schedule":{"tz":"UTC"}}`)
+
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "text", "campaigns", "get", "c1")
+	if res.Err != nil {
+		t.Fatalf("err=%v stderr=%s", res.Err, res.Stderr)
+	}
+	want := "id        c1\n" +
+		"name      Launch\n" +
+		`schedule  {"tz":"UTC"}` + "\n" +
+		"tags      a, b\n"
+	if string(res.Stdout) != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", res.Stdout, want)
+	}
+}
@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/salmonumbrella/instantly-cli/instantly"
+	"github.com/salmonumbrella/instantly-cli/internal/api"
 )
 
 func newWebhooksCmd() *cobra.Command {
@@ -34,6 +38,7 @@ func newWebhooksListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		campaign      string
 		eventType     string
 		queryPairs    []string
@@ -43,38 +48,36 @@ func newWebhooksListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List webhooks (GET /webhooks)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhooks.list", err, nil)
 			}
 
-			q := url.Values{}
-			if limit > 0 {
-				q.Set("limit", fmt.Sprint(limit))
-			}
-			if strings.TrimSpace(startingAfter) != "" {
-				q.Set("starting_after", strings.TrimSpace(startingAfter))
-			}
-			if strings.TrimSpace(campaign) != "" {
-				q.Set("campaign", strings.TrimSpace(campaign))
+			params := instantly.ListWebhooksParams{
+				ListParams: instantly.ListParams{
+					Limit:         limit,
+					StartingAfter: strings.TrimSpace(startingAfter),
+					Query:         url.Values{},
+				},
+				Campaign:  strings.TrimSpace(campaign),
+				EventType: strings.TrimSpace(eventType),
 			}
-			if strings.TrimSpace(eventType) != "" {
-				q.Set("event_type", strings.TrimSpace(eventType))
-			}
-			if err := applyQueryPairs(q, queryPairs); err != nil {
+			if err := applyQueryPairs(params.Query, queryPairs); err != nil {
 				return printError(cmd, "webhooks.list", err, nil)
 			}
 
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/webhooks", q)
-			if err != nil {
-				return printError(cmd, "webhooks.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "webhooks.list", resp, metaFrom(meta, resp))
+			return printList(cmd, "webhooks.list", pages, func(ctx context.Context, cursor string) (any, *api.Meta, error) {
+				if cursor != "" {
+					params.StartingAfter = cursor
+				}
+				return sdkPage(sdk.Webhooks.List(ctx, params))
+			})
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringVar(&campaign, "campaign", "", "Filter by campaign ID (UUID)")
 	cmd.Flags().StringVar(&eventType, "event-type", "", "Filter by event type (e.g. all_events, email_sent)")
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
@@ -87,19 +90,19 @@ func newWebhooksGetCmd() *cobra.Command {
 		Short: "Get webhook (GET /webhooks/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhooks.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "webhooks.get", fmt.Errorf("webhook_id is required"), nil)
+				return printError(cmd, "webhooks.get", usageErrorf("webhook_id is required"), nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/webhooks/"+url.PathEscape(id), nil)
+			webhook, meta, err := sdk.Webhooks.Get(cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "webhooks.get", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "webhooks.get", resp, metaFrom(meta, resp))
+			return printResult(cmd, "webhooks.get", webhook.Raw, metaFrom(meta, webhook.Raw))
 		},
 	}
 	return cmd
@@ -127,7 +130,7 @@ func newWebhooksCreateCmd() *cobra.Command {
 			}
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhooks.create", err, nil)
 			}
@@ -155,18 +158,18 @@ func newWebhooksCreateCmd() *cobra.Command {
 			if strings.TrimSpace(headersJSON) != "" {
 				var v any
 				if err := json.Unmarshal([]byte(headersJSON), &v); err != nil {
-					return printError(cmd, "webhooks.create", fmt.Errorf("invalid --headers-json: %w", err), nil)
+					return printError(cmd, "webhooks.create", usageErrorf("invalid --headers-json: %w", err), nil)
 				}
 				m, ok := v.(map[string]any)
 				if !ok {
-					return printError(cmd, "webhooks.create", fmt.Errorf("--headers-json must be a JSON object"), nil)
+					return printError(cmd, "webhooks.create", usageErrorf("--headers-json must be a JSON object"), nil)
 				}
 				body["headers"] = m
 			}
 
 			target, _ := body["target_hook_url"].(string)
 			if strings.TrimSpace(target) == "" {
-				return printError(cmd, "webhooks.create", fmt.Errorf("--target-url is required (or set target_hook_url in --data-json/--data-file)"), nil)
+				return printError(cmd, "webhooks.create", usageErrorf("--target-url is required (or set target_hook_url in --data-json/--data-file)"), nil)
 			}
 
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/webhooks", nil, body)
@@ -199,13 +202,13 @@ func newWebhooksUpdateCmd() *cobra.Command {
 		Short: "Update webhook (PATCH /webhooks/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhooks.update", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "webhooks.update", fmt.Errorf("webhook_id is required"), nil)
+				return printError(cmd, "webhooks.update", usageErrorf("webhook_id is required"), nil)
 			}
 
 			body, err := readJSONObjectInput(dataJSON, dataFile)
@@ -213,7 +216,7 @@ func newWebhooksUpdateCmd() *cobra.Command {
 				return printError(cmd, "webhooks.update", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "webhooks.update", fmt.Errorf("provide --data-json or --data-file with at least one field"), nil)
+				return printError(cmd, "webhooks.update", usageErrorf("provide --data-json or --data-file with at least one field"), nil)
 			}
 
 			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/webhooks/"+url.PathEscape(id), nil, body)
@@ -237,22 +240,22 @@ func newWebhooksDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "webhooks.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "webhooks.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			sdk, err := sdkFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhooks.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "webhooks.delete", fmt.Errorf("webhook_id is required"), nil)
+				return printError(cmd, "webhooks.delete", usageErrorf("webhook_id is required"), nil)
 			}
 
-			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/webhooks/"+url.PathEscape(id), nil)
+			webhook, meta, err := sdk.Webhooks.Delete(cmdContext(cmd), id)
 			if err != nil {
 				return printError(cmd, "webhooks.delete", err, metaFrom(meta, nil))
 			}
-			return printResult(cmd, "webhooks.delete", resp, metaFrom(meta, resp))
+			return printResult(cmd, "webhooks.delete", webhook.Raw, metaFrom(meta, webhook.Raw))
 		},
 	}
 	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm destructive action")
@@ -264,7 +267,7 @@ func newWebhooksEventTypesCmd() *cobra.Command {
 		Use:   "event-types",
 		Short: "List available webhook event types (GET /webhooks/event-types)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhooks.event_types", err, nil)
 			}
@@ -286,15 +289,15 @@ func newWebhooksTestCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "webhooks.test", fmt.Errorf("refusing to send test payload without --confirm"), nil)
+				return printError(cmd, "webhooks.test", errConfirmRequired("refusing to send test payload without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhooks.test", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "webhooks.test", fmt.Errorf("webhook_id is required"), nil)
+				return printError(cmd, "webhooks.test", usageErrorf("webhook_id is required"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/webhooks/"+url.PathEscape(id)+"/test", nil, nil)
 			if err != nil {
@@ -313,13 +316,13 @@ func newWebhooksResumeCmd() *cobra.Command {
 		Short: "Resume a disabled webhook (POST /webhooks/{id}/resume)",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhooks.resume", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "webhooks.resume", fmt.Errorf("webhook_id is required"), nil)
+				return printError(cmd, "webhooks.resume", usageErrorf("webhook_id is required"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/webhooks/"+url.PathEscape(id)+"/resume", nil, nil)
 			if err != nil {
@@ -349,6 +352,7 @@ func newWebhookEventsListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 
@@ -356,7 +360,7 @@ func newWebhookEventsListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List webhook events (GET /webhook-events)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhook_events.list", err, nil)
 			}
@@ -370,16 +374,13 @@ func newWebhookEventsListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "webhook_events.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/webhook-events", q)
-			if err != nil {
-				return printError(cmd, "webhook_events.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "webhook_events.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "webhook_events.list", "/webhook-events", q, pages)
 		},
 	}
 
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -390,13 +391,13 @@ func newWebhookEventsGetCmd() *cobra.Command {
 		Short: "Get webhook event (GET /webhook-events/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhook_events.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "webhook_events.get", fmt.Errorf("event_id is required"), nil)
+				return printError(cmd, "webhook_events.get", usageErrorf("event_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/webhook-events/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -416,7 +417,7 @@ func newWebhookEventsSummaryCmd() *cobra.Command {
 		Use:   "summary",
 		Short: "Summary of webhook events (GET /webhook-events/summary)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhook_events.summary", err, nil)
 			}
@@ -443,7 +444,7 @@ func newWebhookEventsSummaryByDateCmd() *cobra.Command {
 		Use:   "summary-by-date",
 		Short: "Summary of webhook events by date (GET /webhook-events/summary-by-date)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "webhook_events.summary_by_date", err, nil)
 			}
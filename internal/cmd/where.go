@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/salmonumbrella/instantly-cli/internal/filter"
+)
+
+// listFilter is --where/--sort-by compiled to jq; empty when neither is set.
+var listFilter struct {
+	// list filters and sorts the items of a list response (or bare array).
+	list string
+	// item keeps a single streamed item when it matches every --where.
+	item string
+}
+
+var (
+	wherePathRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*$`)
+	whereOpRe   = regexp.MustCompile(`^\s*([^\s!=<>~]+)\s*(!=|<=|>=|=|<|>|~)\s*(.*?)\s*$`)
+	whereInRe   = regexp.MustCompile(`^\s*(\S+)\s+in\s+(.+?)\s*$`)
+)
+
+// whereHelpers defines _eq, which also matches values that differ only in JSON
+// type ("1" and 1), since flag values carry no type.
+const whereHelpers = `def _eq($a; $b): $a == $b or (($a|type) != ($b|type) and ($a|tostring) == ($b|tostring));`
+
+// compileListFilter builds listFilter from --where and --sort-by.
+func compileListFilter(where []string, sortBy string) error {
+	listFilter.list, listFilter.item = "", ""
+	if len(where) == 0 && strings.TrimSpace(sortBy) == "" {
+		return nil
+	}
+
+	conds := make([]string, 0, len(where))
+	for _, w := range where {
+		c, err := parseWhere(w)
+		if err != nil {
+			return err
+		}
+		conds = append(conds, c)
+	}
+	stages := []string{}
+	if len(conds) > 0 {
+		sel := "select(" + strings.Join(conds, " and ") + ")"
+		listFilter.item = whereHelpers + " " + sel
+		stages = append(stages, "map("+sel+")")
+	}
+	if s := strings.TrimSpace(sortBy); s != "" {
+		field, dir, _ := strings.Cut(s, ":")
+		if !wherePathRe.MatchString(field) {
+			return usageErrorf("invalid --sort-by field %q (expected: a, a.b, optionally :asc or :desc)", field)
+		}
+		stages = append(stages, "sort_by("+jqFieldPath(field)+")")
+		switch dir {
+		case "", "asc":
+		case "desc":
+			stages = append(stages, "reverse")
+		default:
+			return usageErrorf("invalid --sort-by direction %q (expected asc or desc)", dir)
+		}
+	}
+	listFilter.list = fmt.Sprintf(`%s def _items: %s;
+if type == "array" then _items
+elif type == "object" and ((.items | type) == "array") then .items |= _items
+else . end`, whereHelpers, strings.Join(stages, " | "))
+	return nil
+}
+
+// parseWhere compiles one "field op value" clause to a jq condition.
+func parseWhere(s string) (string, error) {
+	var field, op, raw string
+	if m := whereInRe.FindStringSubmatch(s); m != nil {
+		field, op, raw = m[1], "in", m[2]
+	} else if m := whereOpRe.FindStringSubmatch(s); m != nil {
+		field, op, raw = m[1], m[2], m[3]
+	} else {
+		return "", usageErrorf("invalid --where %q (expected: field op value, op one of = != < <= > >= ~ in)", s)
+	}
+	if !wherePathRe.MatchString(field) {
+		return "", usageErrorf("invalid --where field %q (expected: a, a_b, a.b)", field)
+	}
+
+	v := "(" + jqFieldPath(field) + ") as $v | "
+	value := whereValue(raw)
+	switch op {
+	case "=":
+		return "(" + v + "_eq($v; " + jqLiteral(value) + "))", nil
+	case "!=":
+		return "(" + v + "_eq($v; " + jqLiteral(value) + ") | not)", nil
+	case "<", "<=", ">", ">=":
+		typ := "string"
+		switch value.(type) {
+		case json.Number:
+			typ = "number"
+		case string:
+		default:
+			return "", usageErrorf("invalid --where %q: %s needs a number or string", s, op)
+		}
+		return fmt.Sprintf(`(%s($v | type) == "%s" and $v %s %s)`, v, typ, op, jqLiteral(value)), nil
+	case "~":
+		if _, err := regexp.Compile(raw); err != nil {
+			return "", usageErrorf("invalid --where %q: %w", s, err)
+		}
+		return fmt.Sprintf(`(%s($v | type) == "string" and ($v | test(%s; "i")))`, v, jqLiteral(raw)), nil
+	default: // in
+		parts := strings.Split(raw, ",")
+		lits := make([]string, len(parts))
+		for i, p := range parts {
+			lits[i] = jqLiteral(whereValue(strings.TrimSpace(p)))
+		}
+		return "(" + v + "any(" + strings.Join(lits, ", ") + "; _eq($v; .)))", nil
+	}
+}
+
+// whereValue types a flag value: JSON numbers, booleans, null and quoted
+// strings decode as such; anything else is a plain string.
+func whereValue(raw string) any {
+	var v any
+	if err := jsonUnmarshal([]byte(raw), &v); err == nil {
+		switch v.(type) {
+		case json.Number, bool, string, nil:
+			return v
+		}
+	}
+	return raw
+}
+
+func jqLiteral(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// jqFieldPath renders a validated dotted path; "?" skips records where an
+// intermediate value is not an object.
+func jqFieldPath(field string) string {
+	return "." + strings.ReplaceAll(field, ".", "?.") + "?"
+}
+
+// applyListFilter runs --where/--sort-by over resp.
+func applyListFilter(resp any) (any, error) {
+	if listFilter.list == "" {
+		return resp, nil
+	}
+	v, err := normalizeForJQ(resp)
+	if err != nil {
+		return nil, err
+	}
+	return filter.Apply(v, listFilter.list)
+}
+
+// matchesWhere reports whether a streamed item passes every --where.
+func matchesWhere(item any) (bool, error) {
+	if listFilter.item == "" {
+		return true, nil
+	}
+	v, err := normalizeForJQ(item)
+	if err != nil {
+		return false, err
+	}
+	out, err := filter.ApplyAll(v, listFilter.item, filter.Options{})
+	return len(out) > 0, err
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+const whereAccountsBody = `{"items":[` +
+	`{"email":"c@acme.com","warmup_status":1,"daily_limit":20,"stat":{"sent":"5"}},` +
+	`{"email":"a@acme.com","warmup_status":1,"daily_limit":25},` +
+	`{"email":"b@other.io","warmup_status":1,"daily_limit":10},` +
+	`{"email":"d@acme.com","warmup_status":0,"daily_limit":5},` +
+	`{"email":"e@acme.com","warmup_status":1}` +
+	`],"next_starting_after":"e"}`
+
+func emailsOf(t *testing.T, items []any) string {
+	t.Helper()
+	out := make([]string, len(items))
+	for i, it := range items {
+		out[i] = it.(map[string]any)["email"].(string)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestWhereAndSortBy(t *testing.T) {
+	srv := jsonServer(t, whereAccountsBody)
+	run := func(args ...string) string {
+		t.Helper()
+		res := execCLI(t, append([]string{"--api-key", "k", "--base-url", srv.URL, "accounts", "list"}, args...)...)
+		if res.Err != nil {
+			t.Fatalf("%v: err=%v stdout=%s", args, res.Err, res.Stdout)
+		}
+		got := mustJSON(t, res.Stdout).(map[string]any)
+		return emailsOf(t, got["items"].([]any))
+	}
+
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--where", "warmup_status=1", "--where", "daily_limit<30", "--sort-by", "email"}, "a@acme.com,b@other.io,c@acme.com"},
+		{[]string{"--where", "warmup_status = 1", "--where", "daily_limit < 30", "--sort-by", "email:desc"}, "c@acme.com,b@other.io,a@acme.com"},
+		{[]string{"--where", "email ~ ACME", "--where", "warmup_status != 0", "--sort-by", "daily_limit"}, "e@acme.com,c@acme.com,a@acme.com"},
+		{[]string{"--where", "daily_limit in 5, 10"}, "b@other.io,d@acme.com"},
+		{[]string{"--where", "stat.sent=5"}, "c@acme.com"},
+		{[]string{"--where", "email>c"}, "c@acme.com,d@acme.com,e@acme.com"},
+	}
+	for _, tc := range cases {
+		if got := run(tc.args...); got != tc.want {
+			t.Fatalf("%v: got %s, want %s", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestWhere_JSONLStreamsMatchingItems(t *testing.T) {
+	srv := jsonServer(t, whereAccountsBody)
+	res := execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "jsonl", "-r", "--jq", ".email",
+		"--where", "daily_limit>=20", "accounts", "list")
+	if res.Err != nil || string(res.Stdout) != "c@acme.com\na@acme.com\n" {
+		t.Fatalf("err=%v stdout=%q", res.Err, res.Stdout)
+	}
+
+	res = execCLI(t, "--api-key", "k", "--base-url", srv.URL, "--output", "jsonl", "-r", "--jq", ".email",
+		"--where", "daily_limit>=20", "--sort-by", "email", "accounts", "list")
+	if res.Err != nil || string(res.Stdout) != "a@acme.com\nc@acme.com\n" {
+		t.Fatalf("err=%v stdout=%q", res.Err, res.Stdout)
+	}
+}
+
+func TestWhere_InvalidRejectedUpFront(t *testing.T) {
+	for _, args := range [][]string{
+		{"--where", "a..b=1"},
+		{"--where", "status"},
+		{"--where", "1x=2"},
+		{"--where", "a<true"},
+		{"--where", "a~("},
+		{"--sort-by", "a-b"},
+		{"--sort-by", "a:sideways"},
+	} {
+		res := execCLI(t, append([]string{"--api-key", "k", "--base-url", "http://127.0.0.1:1", "accounts", "list"}, args...)...)
+		if res.Err == nil || ExitCode(res.Err) != 2 {
+			t.Fatalf("%v: err=%v", args, res.Err)
+		}
+	}
+}
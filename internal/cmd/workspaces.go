@@ -39,7 +39,7 @@ func newWorkspacesCurrentGetCmd() *cobra.Command {
 		Use:   "get",
 		Short: "Get current workspace (GET /workspaces/current)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspaces.current.get", err, nil)
 			}
@@ -62,7 +62,7 @@ func newWorkspacesCurrentUpdateCmd() *cobra.Command {
 		Use:   "update",
 		Short: "Update current workspace (PATCH /workspaces/current)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspaces.current.update", err, nil)
 			}
@@ -71,7 +71,7 @@ func newWorkspacesCurrentUpdateCmd() *cobra.Command {
 				return printError(cmd, "workspaces.current.update", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "workspaces.current.update", fmt.Errorf("provide --data-json or --data-file with at least one field"), nil)
+				return printError(cmd, "workspaces.current.update", usageErrorf("provide --data-json or --data-file with at least one field"), nil)
 			}
 			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/workspaces/current", nil, body)
 			if err != nil {
@@ -96,9 +96,9 @@ func newWorkspacesCreateCmd() *cobra.Command {
 		Short: "Create a workspace (POST /workspaces/create, requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "workspaces.create", fmt.Errorf("refusing to create workspace without --confirm"), nil)
+				return printError(cmd, "workspaces.create", errConfirmRequired("refusing to create workspace without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspaces.create", err, nil)
 			}
@@ -107,7 +107,7 @@ func newWorkspacesCreateCmd() *cobra.Command {
 				return printError(cmd, "workspaces.create", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "workspaces.create", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "workspaces.create", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/workspaces/create", nil, body)
 			if err != nil {
@@ -133,9 +133,9 @@ func newWorkspacesChangeOwnerCmd() *cobra.Command {
 		Short: "Change workspace owner (POST /workspaces/current/change-owner, requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "workspaces.change_owner", fmt.Errorf("refusing to change owner without --confirm"), nil)
+				return printError(cmd, "workspaces.change_owner", errConfirmRequired("refusing to change owner without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspaces.change_owner", err, nil)
 			}
@@ -144,7 +144,7 @@ func newWorkspacesChangeOwnerCmd() *cobra.Command {
 				return printError(cmd, "workspaces.change_owner", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "workspaces.change_owner", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "workspaces.change_owner", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/workspaces/current/change-owner", nil, body)
 			if err != nil {
@@ -176,7 +176,7 @@ func newWorkspacesWhiteLabelDomainGetCmd() *cobra.Command {
 		Use:   "get",
 		Short: "Get whitelabel domain (GET /workspaces/current/whitelabel-domain)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspaces.whitelabel_domain.get", err, nil)
 			}
@@ -202,9 +202,9 @@ func newWorkspacesWhiteLabelDomainSetCmd() *cobra.Command {
 		Short: "Set whitelabel domain (POST /workspaces/current/whitelabel-domain, requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "workspaces.whitelabel_domain.set", fmt.Errorf("refusing to set whitelabel domain without --confirm"), nil)
+				return printError(cmd, "workspaces.whitelabel_domain.set", errConfirmRequired("refusing to set whitelabel domain without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspaces.whitelabel_domain.set", err, nil)
 			}
@@ -217,7 +217,7 @@ func newWorkspacesWhiteLabelDomainSetCmd() *cobra.Command {
 			}
 			domainVal, ok := body["domain"].(string)
 			if !ok || strings.TrimSpace(domainVal) == "" {
-				return printError(cmd, "workspaces.whitelabel_domain.set", fmt.Errorf("--domain is required (or set in body)"), nil)
+				return printError(cmd, "workspaces.whitelabel_domain.set", usageErrorf("--domain is required (or set in body)"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/workspaces/current/whitelabel-domain", nil, body)
 			if err != nil {
@@ -240,9 +240,9 @@ func newWorkspacesWhiteLabelDomainDeleteCmd() *cobra.Command {
 		Short: "Delete whitelabel domain (DELETE /workspaces/current/whitelabel-domain, requires --confirm)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if !confirm {
-				return printError(cmd, "workspaces.whitelabel_domain.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "workspaces.whitelabel_domain.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspaces.whitelabel_domain.delete", err, nil)
 			}
@@ -277,13 +277,14 @@ func newWorkspaceMembersListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List workspace members (GET /workspace-members)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_members.list", err, nil)
 			}
@@ -297,15 +298,12 @@ func newWorkspaceMembersListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "workspace_members.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/workspace-members", q)
-			if err != nil {
-				return printError(cmd, "workspace_members.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "workspace_members.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "workspace_members.list", "/workspace-members", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -316,13 +314,13 @@ func newWorkspaceMembersGetCmd() *cobra.Command {
 		Short: "Get workspace member (GET /workspace-members/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_members.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "workspace_members.get", fmt.Errorf("member_id is required"), nil)
+				return printError(cmd, "workspace_members.get", usageErrorf("member_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/workspace-members/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -343,7 +341,7 @@ func newWorkspaceMembersCreateCmd() *cobra.Command {
 		Use:   "create",
 		Short: "Create workspace member (POST /workspace-members)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_members.create", err, nil)
 			}
@@ -352,7 +350,7 @@ func newWorkspaceMembersCreateCmd() *cobra.Command {
 				return printError(cmd, "workspace_members.create", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "workspace_members.create", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "workspace_members.create", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/workspace-members", nil, body)
 			if err != nil {
@@ -376,20 +374,20 @@ func newWorkspaceMembersUpdateCmd() *cobra.Command {
 		Short: "Update workspace member (PATCH /workspace-members/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_members.update", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "workspace_members.update", fmt.Errorf("member_id is required"), nil)
+				return printError(cmd, "workspace_members.update", usageErrorf("member_id is required"), nil)
 			}
 			body, err := readJSONObjectInput(dataJSON, dataFile)
 			if err != nil {
 				return printError(cmd, "workspace_members.update", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "workspace_members.update", fmt.Errorf("provide --data-json or --data-file with at least one field"), nil)
+				return printError(cmd, "workspace_members.update", usageErrorf("provide --data-json or --data-file with at least one field"), nil)
 			}
 			resp, meta, err := client.PatchJSON(cmdContext(cmd), "/workspace-members/"+url.PathEscape(id), nil, body)
 			if err != nil {
@@ -411,15 +409,15 @@ func newWorkspaceMembersDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "workspace_members.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "workspace_members.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_members.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "workspace_members.delete", fmt.Errorf("member_id is required"), nil)
+				return printError(cmd, "workspace_members.delete", usageErrorf("member_id is required"), nil)
 			}
 			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/workspace-members/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -452,13 +450,14 @@ func newWorkspaceGroupMembersListCmd() *cobra.Command {
 	var (
 		limit         int
 		startingAfter string
+		pages         pageFlags
 		queryPairs    []string
 	)
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List workspace group members (GET /workspace-group-members)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_group_members.list", err, nil)
 			}
@@ -472,15 +471,12 @@ func newWorkspaceGroupMembersListCmd() *cobra.Command {
 			if err := applyQueryPairs(q, queryPairs); err != nil {
 				return printError(cmd, "workspace_group_members.list", err, nil)
 			}
-			resp, meta, err := client.GetJSON(cmdContext(cmd), "/workspace-group-members", q)
-			if err != nil {
-				return printError(cmd, "workspace_group_members.list", err, metaFrom(meta, nil))
-			}
-			return printResult(cmd, "workspace_group_members.list", resp, metaFrom(meta, resp))
+			return printListGET(cmd, client, "workspace_group_members.list", "/workspace-group-members", q, pages)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 100, "Max results (default 100)")
 	cmd.Flags().StringVar(&startingAfter, "starting-after", "", "Cursor for pagination")
+	addPageFlags(cmd, &pages)
 	cmd.Flags().StringArrayVar(&queryPairs, "query", nil, "Extra query param (repeatable): key=value")
 	return cmd
 }
@@ -491,13 +487,13 @@ func newWorkspaceGroupMembersGetCmd() *cobra.Command {
 		Short: "Get workspace group member (GET /workspace-group-members/{id})",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_group_members.get", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "workspace_group_members.get", fmt.Errorf("group_member_id is required"), nil)
+				return printError(cmd, "workspace_group_members.get", usageErrorf("group_member_id is required"), nil)
 			}
 			resp, meta, err := client.GetJSON(cmdContext(cmd), "/workspace-group-members/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -518,7 +514,7 @@ func newWorkspaceGroupMembersCreateCmd() *cobra.Command {
 		Use:   "create",
 		Short: "Create workspace group member (POST /workspace-group-members)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_group_members.create", err, nil)
 			}
@@ -527,7 +523,7 @@ func newWorkspaceGroupMembersCreateCmd() *cobra.Command {
 				return printError(cmd, "workspace_group_members.create", err, nil)
 			}
 			if len(body) == 0 {
-				return printError(cmd, "workspace_group_members.create", fmt.Errorf("provide --data-json or --data-file"), nil)
+				return printError(cmd, "workspace_group_members.create", usageErrorf("provide --data-json or --data-file"), nil)
 			}
 			resp, meta, err := client.PostJSON(cmdContext(cmd), "/workspace-group-members", nil, body)
 			if err != nil {
@@ -549,15 +545,15 @@ func newWorkspaceGroupMembersDeleteCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !confirm {
-				return printError(cmd, "workspace_group_members.delete", fmt.Errorf("refusing to delete without --confirm"), nil)
+				return printError(cmd, "workspace_group_members.delete", errConfirmRequired("refusing to delete without --confirm"), nil)
 			}
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_group_members.delete", err, nil)
 			}
 			id := strings.TrimSpace(args[0])
 			if id == "" {
-				return printError(cmd, "workspace_group_members.delete", fmt.Errorf("group_member_id is required"), nil)
+				return printError(cmd, "workspace_group_members.delete", usageErrorf("group_member_id is required"), nil)
 			}
 			resp, meta, err := client.DeleteJSON(cmdContext(cmd), "/workspace-group-members/"+url.PathEscape(id), nil)
 			if err != nil {
@@ -575,7 +571,7 @@ func newWorkspaceGroupMembersAdminCmd() *cobra.Command {
 		Use:   "admin",
 		Short: "Get workspace group members admin info (GET /workspace-group-members/admin)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_group_members.admin", err, nil)
 			}
@@ -605,7 +601,7 @@ func newWorkspaceBillingPlanDetailsCmd() *cobra.Command {
 		Use:   "plan-details",
 		Short: "Get plan details (GET /workspace-billing/plan-details)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_billing.plan_details", err, nil)
 			}
@@ -624,7 +620,7 @@ func newWorkspaceBillingSubscriptionDetailsCmd() *cobra.Command {
 		Use:   "subscription-details",
 		Short: "Get subscription details (GET /workspace-billing/subscription-details)",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			client, err := clientFromFlags()
+			client, err := clientFromFlags(cmdContext(cmd))
 			if err != nil {
 				return printError(cmd, "workspace_billing.subscription_details", err, nil)
 			}
@@ -0,0 +1,254 @@
+// Package config reads and writes the CLI's config file of named profiles.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/salmonumbrella/instantly-cli/internal/outfmt"
+)
+
+// DefaultProfile is used when no profile is named and the file sets no current_profile.
+const DefaultProfile = "default"
+
+// File is the config file: profiles keyed by name, plus the one `config use` selected.
+type File struct {
+	CurrentProfile string              `yaml:"current_profile,omitempty"`
+	Profiles       map[string]*Profile `yaml:"profiles,omitempty"`
+}
+
+// Profile holds the settings for one workspace. Values are kept as written and
+// parsed by the flags they feed, so "30s" and "3" mean what they do on the
+// command line.
+type Profile struct {
+	APIKey string `yaml:"api_key,omitempty"`
+	// APIKeyCommand is run through the shell when no key is set elsewhere; its
+	// trimmed stdout is the key (e.g. "op read op://team/instantly/key").
+	APIKeyCommand string `yaml:"api_key_command,omitempty"`
+	BaseURL       string `yaml:"base_url,omitempty"`
+	Output        string `yaml:"output,omitempty"`
+	Timeout       string `yaml:"timeout,omitempty"`
+	Max429Retries string `yaml:"max_429_retries,omitempty"`
+	Max5xxRetries string `yaml:"max_5xx_retries,omitempty"`
+	RetryDelay    string `yaml:"retry_delay,omitempty"`
+	MaxRetryDelay string `yaml:"max_retry_delay,omitempty"`
+	// Flags are default values for any other flag, by flag name
+	// (e.g. cache-ttl: 5m). Lists set repeatable flags.
+	Flags map[string]any `yaml:"flags,omitempty"`
+}
+
+// Keys lists the settable profile keys, besides "flags.<name>".
+var Keys = []string{
+	"api_key", "api_key_command", "base_url", "output", "timeout",
+	"max_429_retries", "max_5xx_retries", "retry_delay", "max_retry_delay",
+}
+
+// DefaultPath is config.yaml under the user config dir ($XDG_CONFIG_HOME/instantly on Linux).
+func DefaultPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// Dir is the CLI's per-user configuration directory.
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "instantly"), nil
+}
+
+// Load reads path. A missing file is an empty config, not an error.
+func Load(path string) (*File, error) {
+	f := &File{}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	if err := yaml.Unmarshal(b, f); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Save writes the file with owner-only permissions, since it may hold API keys.
+func (f *File) Save(path string) error {
+	b, err := yaml.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return writePrivate(path, b)
+}
+
+// Resolve picks the profile name to use: name if set, else current_profile,
+// else "default" when it exists. It returns "" when there is nothing to apply.
+func (f *File) Resolve(name string) (string, error) {
+	explicit := name != ""
+	if name == "" {
+		name = f.CurrentProfile
+		explicit = name != ""
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+	if _, ok := f.Profiles[name]; !ok {
+		if explicit {
+			return "", fmt.Errorf("profile %q not found (have: %s)", name, strings.Join(f.Names(), ", "))
+		}
+		return "", nil
+	}
+	return name, nil
+}
+
+// Names returns the profile names, sorted.
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns a profile value by key ("timeout", "flags.cache-ttl").
+func (p *Profile) Get(key string) (any, bool) {
+	if name, ok := strings.CutPrefix(key, "flags."); ok {
+		v, ok := p.Flags[name]
+		return v, ok
+	}
+	field := p.field(key)
+	if field == nil {
+		return nil, false
+	}
+	return *field, *field != ""
+}
+
+// Set validates and stores a profile value by key. An empty value clears it.
+func (p *Profile) Set(key, value string) error {
+	if name, ok := strings.CutPrefix(key, "flags."); ok {
+		if name == "" {
+			return fmt.Errorf("missing flag name in %q", key)
+		}
+		if value == "" {
+			delete(p.Flags, name)
+			return nil
+		}
+		if p.Flags == nil {
+			p.Flags = map[string]any{}
+		}
+		p.Flags[name] = value
+		return nil
+	}
+	field := p.field(key)
+	if field == nil {
+		return fmt.Errorf("unknown config key %q (expected one of %s, or flags.<name>)", key, strings.Join(Keys, ", "))
+	}
+	if value != "" {
+		if err := validate(key, value); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+	}
+	*field = value
+	return nil
+}
+
+// FlagValues maps the profile to flag names and values, typed keys first and
+// then flags.<name>, in a stable order.
+func (p *Profile) FlagValues() [][2]string {
+	var out [][2]string
+	for _, kv := range [][2]string{
+		{"api-key", p.APIKey},
+		{"base-url", p.BaseURL},
+		{"output", p.Output},
+		{"timeout", p.Timeout},
+		{"max-429-retries", p.Max429Retries},
+		{"max-5xx-retries", p.Max5xxRetries},
+		{"retry-delay", p.RetryDelay},
+		{"max-retry-delay", p.MaxRetryDelay},
+	} {
+		if kv[1] != "" {
+			out = append(out, kv)
+		}
+	}
+	names := make([]string, 0, len(p.Flags))
+	for name := range p.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		switch v := p.Flags[name].(type) {
+		case []any:
+			for _, it := range v {
+				out = append(out, [2]string{name, fmt.Sprint(it)})
+			}
+		default:
+			out = append(out, [2]string{name, fmt.Sprint(v)})
+		}
+	}
+	return out
+}
+
+func (p *Profile) field(key string) *string {
+	switch key {
+	case "api_key":
+		return &p.APIKey
+	case "api_key_command":
+		return &p.APIKeyCommand
+	case "base_url":
+		return &p.BaseURL
+	case "output":
+		return &p.Output
+	case "timeout":
+		return &p.Timeout
+	case "max_429_retries":
+		return &p.Max429Retries
+	case "max_5xx_retries":
+		return &p.Max5xxRetries
+	case "retry_delay":
+		return &p.RetryDelay
+	case "max_retry_delay":
+		return &p.MaxRetryDelay
+	}
+	return nil
+}
+
+func validate(key, value string) error {
+	switch key {
+	case "timeout", "retry_delay", "max_retry_delay":
+		_, err := time.ParseDuration(value)
+		return err
+	case "max_429_retries", "max_5xx_retries":
+		n, err := strconv.Atoi(value)
+		if err == nil && n < 0 {
+			err = errors.New("must be >= 0")
+		}
+		return err
+	case "output":
+		_, err := outfmt.ParseMode(value)
+		return err
+	}
+	return nil
+}
+
+// MaskSecret keeps the last 4 characters of a key.
+func MaskSecret(s string) string {
+	if len(s) <= 8 {
+		return "****"
+	}
+	return "****" + s[len(s)-4:]
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config.yaml")
+
+	f, err := Load(path)
+	if err != nil || len(f.Profiles) != 0 {
+		t.Fatalf("missing file: f=%#v err=%v", f, err)
+	}
+
+	f.CurrentProfile = "a"
+	f.Profiles = map[string]*Profile{"a": {APIKey: "k1", Timeout: "30s", Flags: map[string]any{"cache-ttl": "5m"}}}
+	if err := f.Save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Fatalf("stat: %v %v", fi, err)
+	}
+	got, err := Load(path)
+	if err != nil || !reflect.DeepEqual(got, f) {
+		t.Fatalf("got=%#v err=%v", got, err)
+	}
+}
+
+func TestLoad_YAMLScalarsAndLists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	body := "profiles:\n  a:\n    max_429_retries: 3\n    flags:\n      header: [X-A=1, X-B=2]\n      all: true\n"
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	want := [][2]string{{"max-429-retries", "3"}, {"all", "true"}, {"header", "X-A=1"}, {"header", "X-B=2"}}
+	if got := f.Profiles["a"].FlagValues(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got=%v", got)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	f := &File{Profiles: map[string]*Profile{"default": {}, "b": {}}}
+	for _, tc := range []struct {
+		current, name, want string
+		wantErr             bool
+	}{
+		{"", "", "default", false},
+		{"b", "", "b", false},
+		{"b", "default", "default", false},
+		{"", "nope", "", true},
+		{"gone", "", "", true},
+	} {
+		f.CurrentProfile = tc.current
+		got, err := f.Resolve(tc.name)
+		if got != tc.want || (err != nil) != tc.wantErr {
+			t.Fatalf("%+v: got=%q err=%v", tc, got, err)
+		}
+	}
+	if got, err := (&File{}).Resolve(""); got != "" || err != nil {
+		t.Fatalf("empty: got=%q err=%v", got, err)
+	}
+}
+
+func TestProfileSetValidates(t *testing.T) {
+	p := &Profile{}
+	for _, tc := range [][2]string{{"timeout", "soon"}, {"max_429_retries", "-1"}, {"output", "xml"}, {"nope", "1"}, {"flags.", "1"}} {
+		if err := p.Set(tc[0], tc[1]); err == nil {
+			t.Fatalf("%v: expected error", tc)
+		}
+	}
+	if err := p.Set("flags.cache-ttl", "5m"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Set("timeout", "45s"); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := p.Get("timeout"); !ok || v != "45s" {
+		t.Fatalf("timeout=%v", v)
+	}
+	if err := p.Set("timeout", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.Get("timeout"); ok {
+		t.Fatalf("timeout not cleared")
+	}
+}
@@ -0,0 +1,225 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Credential is an API key saved by `auth login`, with the workspace it was
+// verified against.
+type Credential struct {
+	APIKey        string    `json:"api_key"`
+	WorkspaceID   string    `json:"workspace_id,omitempty"`
+	WorkspaceName string    `json:"workspace_name,omitempty"`
+	SavedAt       time.Time `json:"saved_at"`
+}
+
+// Credentials is an AES-256-GCM encrypted file of credentials keyed by profile.
+//
+// The key comes from Passphrase (PBKDF2-SHA256) when set, else from a random
+// key file created next to the credentials on first save. A key file protects
+// against the credentials leaking on their own (backups, dotfile repos, grep),
+// not against someone who can read both files.
+type Credentials struct {
+	Path       string
+	KeyPath    string
+	Passphrase string
+}
+
+// credentialsFile is the on-disk envelope; Ciphertext decrypts to map[profile]Credential.
+type credentialsFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt,omitempty"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+const (
+	kdfKeyFile = "keyfile"
+	kdfPBKDF2  = "pbkdf2-sha256"
+
+	pbkdf2Iterations = 600_000
+)
+
+// CredentialsFor returns the store that sits beside the config file at configPath.
+func CredentialsFor(configPath, passphrase string) *Credentials {
+	dir := filepath.Dir(configPath)
+	return &Credentials{
+		Path:       filepath.Join(dir, "credentials.enc"),
+		KeyPath:    filepath.Join(dir, "credentials.key"),
+		Passphrase: passphrase,
+	}
+}
+
+// Get returns the credential saved for profile.
+func (c *Credentials) Get(profile string) (Credential, bool, error) {
+	all, err := c.load()
+	if err != nil {
+		return Credential{}, false, err
+	}
+	cred, ok := all[profile]
+	return cred, ok, nil
+}
+
+// Put saves cred for profile.
+func (c *Credentials) Put(profile string, cred Credential) error {
+	all, err := c.load()
+	if err != nil {
+		return err
+	}
+	all[profile] = cred
+	return c.save(all)
+}
+
+// Delete removes profile's credential and reports whether there was one.
+func (c *Credentials) Delete(profile string) (bool, error) {
+	all, err := c.load()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := all[profile]; !ok {
+		return false, nil
+	}
+	delete(all, profile)
+	return true, c.save(all)
+}
+
+func (c *Credentials) load() (map[string]Credential, error) {
+	all := map[string]Credential{}
+	b, err := os.ReadFile(c.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read credentials: %w", err)
+	}
+	var f credentialsFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("parse credentials %s: %w", c.Path, err)
+	}
+	key, err := c.key(f.KDF, f.Salt, false)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credentials %s: wrong key or passphrase, or the file is damaged", c.Path)
+	}
+	if err := json.Unmarshal(plain, &all); err != nil {
+		return nil, fmt.Errorf("parse credentials %s: %w", c.Path, err)
+	}
+	return all, nil
+}
+
+func (c *Credentials) save(all map[string]Credential) error {
+	if len(all) == 0 {
+		if err := os.Remove(c.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("write credentials: %w", err)
+		}
+		return nil
+	}
+	plain, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	f := credentialsFile{Version: 1, KDF: kdfKeyFile}
+	if c.Passphrase != "" {
+		f.KDF = kdfPBKDF2
+		f.Salt = make([]byte, 16)
+		if _, err := rand.Read(f.Salt); err != nil {
+			return err
+		}
+	}
+	key, err := c.key(f.KDF, f.Salt, true)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	f.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return err
+	}
+	f.Ciphertext = gcm.Seal(nil, f.Nonce, plain, nil)
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return writePrivate(c.Path, b)
+}
+
+// key derives or reads the encryption key; create makes the key file if missing.
+func (c *Credentials) key(kdf string, salt []byte, create bool) ([]byte, error) {
+	switch kdf {
+	case kdfPBKDF2:
+		if c.Passphrase == "" {
+			return nil, errors.New("credentials are passphrase-protected: set INSTANTLY_CREDENTIALS_PASSPHRASE")
+		}
+		return pbkdf2.Key(sha256.New, c.Passphrase, salt, pbkdf2Iterations, 32)
+	case kdfKeyFile:
+		key, err := os.ReadFile(c.KeyPath)
+		if errors.Is(err, fs.ErrNotExist) && create {
+			key = make([]byte, 32)
+			if _, err := rand.Read(key); err != nil {
+				return nil, err
+			}
+			return key, writePrivate(c.KeyPath, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read credentials key: %w", err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("credentials key %s is damaged", c.KeyPath)
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported credentials kdf %q", kdf)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// writePrivate writes b to path with owner-only permissions, atomically.
+func writePrivate(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
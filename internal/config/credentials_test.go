@@ -0,0 +1,64 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCredentials_KeyFileRoundTrip(t *testing.T) {
+	c := CredentialsFor(filepath.Join(t.TempDir(), "config.yaml"), "")
+	if _, ok, err := c.Get("a"); ok || err != nil {
+		t.Fatalf("empty store: ok=%v err=%v", ok, err)
+	}
+
+	want := Credential{APIKey: "sk-secret-1", WorkspaceName: "Acme", SavedAt: time.Unix(1700000000, 0).UTC()}
+	if err := c.Put("a", want); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	for _, p := range []string{c.Path, c.KeyPath} {
+		if fi, err := os.Stat(p); err != nil || fi.Mode().Perm() != 0o600 {
+			t.Fatalf("stat %s: %v %v", p, fi, err)
+		}
+	}
+	if b, _ := os.ReadFile(c.Path); bytes.Contains(b, []byte("sk-secret-1")) {
+		t.Fatalf("key stored in plaintext: %s", b)
+	}
+	got, ok, err := c.Get("a")
+	if err != nil || !ok || got != want {
+		t.Fatalf("got=%#v ok=%v err=%v", got, ok, err)
+	}
+
+	if removed, err := c.Delete("a"); !removed || err != nil {
+		t.Fatalf("delete: %v %v", removed, err)
+	}
+	if removed, err := c.Delete("a"); removed || err != nil {
+		t.Fatalf("second delete: %v %v", removed, err)
+	}
+	if _, err := os.Stat(c.Path); !os.IsNotExist(err) {
+		t.Fatalf("empty store should remove the file: %v", err)
+	}
+}
+
+func TestCredentials_Passphrase(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	if err := CredentialsFor(config, "hunter2").Put("a", Credential{APIKey: "k"}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if _, err := os.Stat(CredentialsFor(config, "").KeyPath); !os.IsNotExist(err) {
+		t.Fatalf("passphrase mode should not create a key file: %v", err)
+	}
+
+	if got, ok, err := CredentialsFor(config, "hunter2").Get("a"); err != nil || !ok || got.APIKey != "k" {
+		t.Fatalf("got=%#v ok=%v err=%v", got, ok, err)
+	}
+	if _, _, err := CredentialsFor(config, "wrong").Get("a"); err == nil || !strings.Contains(err.Error(), "wrong key or passphrase") {
+		t.Fatalf("wrong passphrase: %v", err)
+	}
+	if _, _, err := CredentialsFor(config, "").Get("a"); err == nil || !strings.Contains(err.Error(), "INSTANTLY_CREDENTIALS_PASSPHRASE") {
+		t.Fatalf("missing passphrase: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WorkspaceName is a profile's workspace name and when it was looked up.
+type WorkspaceName struct {
+	Name      string    `json:"name"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// WorkspaceNames remembers the workspace names --profiles looked up for
+// profiles without a saved login, so later runs need not ask again.
+type WorkspaceNames struct {
+	Path string
+}
+
+// WorkspaceNamesFor returns the cache that sits beside the config file at configPath.
+func WorkspaceNamesFor(configPath string) *WorkspaceNames {
+	return &WorkspaceNames{Path: filepath.Join(filepath.Dir(configPath), "workspaces.json")}
+}
+
+// Load returns the cached names by profile; a missing or unreadable file is empty.
+func (w *WorkspaceNames) Load() map[string]WorkspaceName {
+	names := map[string]WorkspaceName{}
+	if b, err := os.ReadFile(w.Path); err == nil {
+		_ = json.Unmarshal(b, &names)
+	}
+	return names
+}
+
+// Save replaces the cached names.
+func (w *WorkspaceNames) Save(names map[string]WorkspaceName) error {
+	b, err := json.MarshalIndent(names, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writePrivate(w.Path, b)
+}
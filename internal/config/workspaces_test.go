@@ -0,0 +1,22 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWorkspaceNames_RoundTrip(t *testing.T) {
+	w := WorkspaceNamesFor(filepath.Join(t.TempDir(), "config.yaml"))
+	if got := w.Load(); len(got) != 0 {
+		t.Fatalf("missing file: got %v", got)
+	}
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := w.Save(map[string]WorkspaceName{"a": {Name: "Acme", FetchedAt: at}}); err != nil {
+		t.Fatal(err)
+	}
+	got := w.Load()["a"]
+	if got.Name != "Acme" || !got.FetchedAt.Equal(at) {
+		t.Fatalf("got %+v", got)
+	}
+}
@@ -3,15 +3,30 @@ package filter
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/itchyny/gojq"
+
+	"github.com/salmonumbrella/instantly-cli/internal/jsonnum"
 )
 
 var jsonMarshal = json.Marshal
-var jsonUnmarshal = json.Unmarshal
 
-var runQuery = func(q *gojq.Query, data any) gojq.Iter { return q.Run(data) }
+// jsonUnmarshal keeps numbers as json.Number, which gojq compares and computes
+// with natively while passing them through unchanged.
+var jsonUnmarshal = jsonnum.Unmarshal
+
+var runQuery = func(c *gojq.Code, data any, values ...any) gojq.Iter { return c.Run(data, values...) }
+
+// Options configures a jq run beyond the expression itself.
+type Options struct {
+	// Vars are bound as $name, like jq's --arg/--argjson.
+	Vars map[string]any
+	// ModulePaths are searched for modules named by `import "name" as n;`
+	// (name.jq). The "instantly" module falls back to a built-in library.
+	ModulePaths []string
+}
 
 // NormalizeExpression fixes shell-escaped operators in jq expressions.
 // Zsh escapes ! to \! even in single quotes, breaking operators like !=.
@@ -20,10 +35,32 @@ func NormalizeExpression(expr string) string {
 }
 
 // Apply applies a JQ filter expression to the input data.
-func Apply(data any, expression string) (result any, err error) {
+func Apply(data any, expression string) (any, error) {
+	return ApplyWith(data, expression, Options{})
+}
+
+// ApplyWith is Apply with variables and modules. A single output is returned
+// as-is; zero or several outputs are returned as an array.
+func ApplyWith(data any, expression string, opts Options) (any, error) {
 	if strings.TrimSpace(expression) == "" {
 		return data, nil
 	}
+	results, err := ApplyAll(data, expression, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return results, nil
+}
+
+// ApplyAll runs expression and returns every output separately, for callers
+// that print one line per output (jq -r).
+func ApplyAll(data any, expression string, opts Options) (results []any, err error) {
+	if strings.TrimSpace(expression) == "" {
+		return []any{data}, nil
+	}
 
 	// Normalize data to JSON-compatible types. gojq panics on unknown types.
 	data, err = normalize(data)
@@ -37,16 +74,36 @@ func Apply(data any, expression string) (result any, err error) {
 		return nil, fmt.Errorf("invalid jq expression: %w", err)
 	}
 
+	names := make([]string, 0, len(opts.Vars))
+	for name := range opts.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	vars := make([]string, len(names))
+	values := make([]any, len(names))
+	for i, name := range names {
+		vars[i] = "$" + name
+		if values[i], err = normalize(opts.Vars[name]); err != nil {
+			return nil, err
+		}
+	}
+	code, err := gojq.Compile(query,
+		gojq.WithVariables(vars),
+		gojq.WithModuleLoader(newModuleLoader(opts.ModulePaths)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jq expression: %w", err)
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
 			// Convert gojq panics (usually "invalid type") into errors.
 			err = fmt.Errorf("jq panic: %v", r)
-			result = nil
+			results = nil
 		}
 	}()
 
-	iter := runQuery(query, data)
-	var results []any
+	iter := runQuery(code, data, values...)
 	for {
 		v, ok := iter.Next()
 		if !ok {
@@ -57,10 +114,6 @@ func Apply(data any, expression string) (result any, err error) {
 		}
 		results = append(results, v)
 	}
-
-	if len(results) == 1 {
-		return results[0], nil
-	}
 	return results, nil
 }
 
@@ -3,6 +3,8 @@ package filter
 import (
 	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/itchyny/gojq"
@@ -94,7 +96,7 @@ func TestApply_NormalizeError(t *testing.T) {
 func TestApply_PanicRecovery(t *testing.T) {
 	old := runQuery
 	t.Cleanup(func() { runQuery = old })
-	runQuery = func(_ *gojq.Query, _ any) gojq.Iter { panic("boom") }
+	runQuery = func(_ *gojq.Code, _ any, _ ...any) gojq.Iter { panic("boom") }
 
 	_, err := Apply(map[string]any{"a": 1}, ".a")
 	if err == nil {
@@ -148,3 +150,70 @@ func TestApplyToJSON_FilterError(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestApply_JSONNumbers(t *testing.T) {
+	in := map[string]any{"items": []any{
+		map[string]any{"id": json.Number("12345678901234567890"), "n": json.Number("3")},
+		map[string]any{"id": json.Number("12345678901234567891"), "n": json.Number("30")},
+	}}
+	out, err := Apply(in, `[.items[] | select(.n > 10) | .id]`)
+	if err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	b, _ := json.Marshal(out)
+	if string(b) != `[12345678901234567891]` {
+		t.Fatalf("out=%s", b)
+	}
+
+	filtered, err := ApplyToJSON([]byte(`{"ts":1700000000123,"big":1e+06}`), `.ts`)
+	if err != nil || string(filtered) != "1700000000123" {
+		t.Fatalf("filtered=%s err=%v", filtered, err)
+	}
+}
+
+func TestApplyWith_Vars(t *testing.T) {
+	data := map[string]any{"items": []any{map[string]any{"email": "a@x.com", "n": 1}, map[string]any{"email": "b@x.com", "n": 2}}}
+	got, err := ApplyWith(data, `[.items[] | select(.email == $who or .n > $min.n) | .email]`, Options{
+		Vars: map[string]any{"who": `a@x.com"; halt`, "min": map[string]any{"n": 1}},
+	})
+	if err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if arr := got.([]any); len(arr) != 1 || arr[0] != "b@x.com" {
+		t.Fatalf("got=%#v", got)
+	}
+}
+
+func TestApplyAll_Modules(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "mine.jq"), []byte(`def emails: .items[].email;`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]any{"items": []any{
+		map[string]any{"id": "1", "email": "a@x.com", "status": 1},
+		map[string]any{"id": "2", "email": "b@x.com", "status": 2},
+	}}
+	opts := Options{ModulePaths: []string{dir}}
+
+	got, err := ApplyAll(data, `import "mine" as m; m::emails`, opts)
+	if err != nil || len(got) != 2 || got[1] != "b@x.com" {
+		t.Fatalf("got=%#v err=%v", got, err)
+	}
+
+	// Built-in module, until a file of the same name shadows it.
+	got, err = ApplyAll(data, `import "instantly" as i; [i::active_senders | .id]`, opts)
+	if err != nil || len(got) != 1 || got[0].([]any)[0] != "1" {
+		t.Fatalf("got=%#v err=%v", got, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "instantly.jq"), []byte(`def active_senders: "custom";`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ApplyAll(data, `import "instantly" as i; i::active_senders`, opts)
+	if err != nil || got[0] != "custom" {
+		t.Fatalf("got=%#v err=%v", got, err)
+	}
+
+	if _, err := ApplyAll(data, `import "missing" as x; x::f`, opts); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -0,0 +1,52 @@
+package filter
+
+import (
+	_ "embed"
+	"os"
+	"path/filepath"
+
+	"github.com/itchyny/gojq"
+)
+
+// BuiltinModule is the module name served from the embedded library when no
+// file of that name exists in the module paths.
+const BuiltinModule = "instantly"
+
+//go:embed instantly.jq
+var builtinModule string
+
+// moduleLoader loads name.jq from the configured paths, like gojq's own
+// loader, and falls back to the built-in "instantly" module.
+type moduleLoader struct {
+	paths []string
+	local gojq.ModuleLoader
+}
+
+func newModuleLoader(paths []string) *moduleLoader {
+	return &moduleLoader{paths: paths, local: gojq.NewModuleLoader(paths)}
+}
+
+func (l *moduleLoader) LoadModuleWithMeta(name string, meta map[string]any) (*gojq.Query, error) {
+	if name == BuiltinModule && !l.exists(name+".jq") {
+		return gojq.Parse(builtinModule)
+	}
+	return l.local.(interface {
+		LoadModuleWithMeta(string, map[string]any) (*gojq.Query, error)
+	}).LoadModuleWithMeta(name, meta)
+}
+
+// LoadJSONWithMeta serves `import "name" as $data;` from name.json files.
+func (l *moduleLoader) LoadJSONWithMeta(name string, meta map[string]any) (any, error) {
+	return l.local.(interface {
+		LoadJSONWithMeta(string, map[string]any) (any, error)
+	}).LoadJSONWithMeta(name, meta)
+}
+
+func (l *moduleLoader) exists(file string) bool {
+	for _, p := range l.paths {
+		if _, err := os.Stat(filepath.Join(p, file)); err == nil {
+			return true
+		}
+	}
+	return false
+}
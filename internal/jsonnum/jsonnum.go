@@ -0,0 +1,27 @@
+// Package jsonnum decodes JSON with numbers kept as json.Number, so values
+// re-encode byte-for-byte (no float64 rounding of large IDs or timestamps).
+package jsonnum
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Unmarshal is json.Unmarshal with UseNumber. Data after the top-level value is
+// an error.
+func Unmarshal(b []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			err = errors.New("unexpected data after top-level value")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package jsonnum
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnmarshal(t *testing.T) {
+	var out any
+	if err := Unmarshal([]byte(`{"id":12345678901234567890,"f":1.50}`), &out); err != nil {
+		t.Fatalf("err=%v", err)
+	}
+	if b, _ := json.Marshal(out); string(b) != `{"f":1.50,"id":12345678901234567890}` {
+		t.Fatalf("got %s", b)
+	}
+	if err := Unmarshal([]byte(`{} {}`), &out); err == nil {
+		t.Fatalf("expected trailing data error")
+	}
+}
@@ -0,0 +1,27 @@
+package outfmt
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// PrintDelimited writes headers and rows as CSV, or TSV when mode is TSV.
+// Cells containing the separator, quotes or newlines are quoted per RFC 4180.
+func PrintDelimited(w io.Writer, mode Mode, headers []string, rows [][]string, noHeaders bool) error {
+	cw := csv.NewWriter(w)
+	if mode == TSV {
+		cw.Comma = '\t'
+	}
+	if !noHeaders {
+		if err := cw.Write(headers); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
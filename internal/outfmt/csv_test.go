@@ -0,0 +1,26 @@
+package outfmt
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintDelimited(t *testing.T) {
+	rows := [][]string{{"1", "hello, world"}, {"2", "tab\there"}}
+
+	var csvOut bytes.Buffer
+	if err := PrintDelimited(&csvOut, CSV, []string{"id", "note"}, rows, false); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if want := "id,note\n1,\"hello, world\"\n2,tab\there\n"; csvOut.String() != want {
+		t.Fatalf("csv got %q want %q", csvOut.String(), want)
+	}
+
+	var tsvOut bytes.Buffer
+	if err := PrintDelimited(&tsvOut, TSV, []string{"id", "note"}, rows, true); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if want := "1\thello, world\n2\t\"tab\there\"\n"; tsvOut.String() != want {
+		t.Fatalf("tsv got %q want %q", tsvOut.String(), want)
+	}
+}
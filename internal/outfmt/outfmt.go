@@ -14,6 +14,11 @@ const (
 	JSON  Mode = "json"
 	JSONL Mode = "jsonl"
 	Agent Mode = "agent"
+	CSV   Mode = "csv"
+	TSV   Mode = "tsv"
+	YAML  Mode = "yaml"
+	// Template renders with --template / --template-file.
+	Template Mode = "template"
 )
 
 type ctxKey int
@@ -39,13 +44,18 @@ func ParseMode(s string) (Mode, error) {
 	switch Mode(s) {
 	case "", JSON:
 		return JSON, nil
-	case Text, JSONL, Agent:
+	case Text, JSONL, Agent, CSV, TSV, YAML, Template:
 		return Mode(s), nil
 	default:
-		return "", fmt.Errorf("invalid --output %q (expected text, json, jsonl, agent)", s)
+		return "", fmt.Errorf("invalid --output %q (expected text, json, jsonl, agent, csv, tsv, yaml, template)", s)
 	}
 }
 
+// Delimited reports whether m is a spreadsheet format (csv or tsv).
+func (m Mode) Delimited() bool {
+	return m == CSV || m == TSV
+}
+
 func PrintJSON(w io.Writer, v any) error {
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
@@ -0,0 +1,142 @@
+package outfmt
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+const (
+	columnGap      = 2
+	minColumnWidth = 6
+	ellipsis       = "…"
+)
+
+// TableOptions controls PrintTable.
+type TableOptions struct {
+	// Width is the maximum line width; 0 means unlimited.
+	Width     int
+	NoHeaders bool
+}
+
+// TerminalWidth returns w's terminal width, falling back to $COLUMNS, or 0 when
+// w is not a terminal (output is piped and should not be truncated).
+func TerminalWidth(w io.Writer) int {
+	if f, ok := w.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return 0
+}
+
+// PrintTable writes rows as aligned columns. When the natural width exceeds
+// opts.Width, the widest columns are shrunk and their cells truncated with "…".
+func PrintTable(w io.Writer, headers []string, rows [][]string, opts TableOptions) error {
+	widths := make([]int, len(headers))
+	if !opts.NoHeaders {
+		for i, h := range headers {
+			widths[i] = utf8.RuneCountInString(h)
+		}
+	}
+	for _, row := range rows {
+		for i := range headers {
+			if i < len(row) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(row[i]))
+			}
+		}
+	}
+	fitWidths(widths, opts.Width)
+
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		for i := range headers {
+			cell := ""
+			if i < len(cells) {
+				cell = truncate(cells[i], widths[i])
+			}
+			if i == len(headers)-1 {
+				b.WriteString(cell)
+				break
+			}
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+columnGap))
+		}
+		b.WriteString("\n")
+	}
+	if !opts.NoHeaders {
+		writeRow(headers)
+	}
+	for _, row := range rows {
+		writeRow(row)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// PrintKV writes key/value pairs as an aligned block, truncating values to fit width (0 = unlimited).
+func PrintKV(w io.Writer, keys, values []string, width int) error {
+	keyWidth := 0
+	for _, k := range keys {
+		keyWidth = max(keyWidth, utf8.RuneCountInString(k))
+	}
+	valueWidth := 0
+	if width > 0 {
+		valueWidth = max(width-keyWidth-columnGap, minColumnWidth)
+	}
+	var b strings.Builder
+	for i, k := range keys {
+		v := values[i]
+		if valueWidth > 0 {
+			v = truncate(v, valueWidth)
+		}
+		fmt.Fprintf(&b, "%-*s%s%s\n", keyWidth, k, strings.Repeat(" ", columnGap), v)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// fitWidths shrinks the widest columns until the row fits in limit.
+func fitWidths(widths []int, limit int) {
+	if limit <= 0 || len(widths) == 0 {
+		return
+	}
+	total := func() int {
+		n := columnGap * (len(widths) - 1)
+		for _, w := range widths {
+			n += w
+		}
+		return n
+	}
+	for total() > limit {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			return
+		}
+		widths[widest]--
+	}
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width <= 1 {
+		return ellipsis
+	}
+	r := []rune(s)
+	return string(r[:width-1]) + ellipsis
+}
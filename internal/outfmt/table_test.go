@@ -0,0 +1,57 @@
+package outfmt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintTable_AlignsColumns(t *testing.T) {
+	var b bytes.Buffer
+	rows := [][]string{{"1", "alpha", "active"}, {"22", "b", ""}}
+	if err := PrintTable(&b, []string{"ID", "NAME", "STATUS"}, rows, TableOptions{}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	want := "ID  NAME   STATUS\n" +
+		"1   alpha  active\n" +
+		"22  b      \n"
+	if b.String() != want {
+		t.Fatalf("got:\n%q\nwant:\n%q", b.String(), want)
+	}
+}
+
+func TestPrintTable_NoHeaders(t *testing.T) {
+	var b bytes.Buffer
+	if err := PrintTable(&b, []string{"ID", "NAME"}, [][]string{{"1", "x"}}, TableOptions{NoHeaders: true}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if b.String() != "1  x\n" {
+		t.Fatalf("got %q", b.String())
+	}
+}
+
+func TestPrintTable_FitsWidth(t *testing.T) {
+	var b bytes.Buffer
+	long := strings.Repeat("x", 50)
+	if err := PrintTable(&b, []string{"ID", "SUBJECT"}, [][]string{{"1", long}}, TableOptions{Width: 20}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+		if n := len([]rune(line)); n > 20 {
+			t.Fatalf("line %q is %d wide", line, n)
+		}
+	}
+	if !strings.Contains(b.String(), "…") {
+		t.Fatalf("expected truncation marker, got %q", b.String())
+	}
+}
+
+func TestPrintKV(t *testing.T) {
+	var b bytes.Buffer
+	if err := PrintKV(&b, []string{"id", "status"}, []string{"c1", "active"}, 0); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if b.String() != "id      c1\nstatus  active\n" {
+		t.Fatalf("got %q", b.String())
+	}
+}
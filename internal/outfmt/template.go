@@ -0,0 +1,137 @@
+package outfmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateFuncs are the helpers available to --template, on top of text/template's builtins.
+var TemplateFuncs = template.FuncMap{
+	"json":     templateJSON,
+	"pretty":   templatePrettyJSON,
+	"truncate": templateTruncate,
+	"date":     templateDate,
+	"ago":      templateAgo,
+	"join":     templateJoin,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"default":  templateDefault,
+}
+
+// templateNow is stubbed in tests.
+var templateNow = time.Now
+
+// ParseTemplate parses a --template body with TemplateFuncs.
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("output").Funcs(TemplateFuncs).Parse(text)
+}
+
+// PrintTemplate executes t against v.
+func PrintTemplate(w io.Writer, t *template.Template, v any) error {
+	return t.Execute(w, v)
+}
+
+func templateJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func templatePrettyJSON(v any) (string, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	return string(b), err
+}
+
+// templateTruncate shortens s to n characters, ending in "…" when cut.
+func templateTruncate(n int, v any) string {
+	return truncate(fmt.Sprint(v), n)
+}
+
+// templateDate formats an RFC 3339 string or a unix timestamp (seconds or
+// milliseconds) with a Go layout; values that are not times pass through.
+func templateDate(layout string, v any) string {
+	t, ok := toTime(v)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	return t.Format(layout)
+}
+
+// templateAgo renders the time since v, rounded to a whole unit ("3h", "2d").
+func templateAgo(v any) string {
+	t, ok := toTime(v)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	d := templateNow().Sub(t)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+func templateJoin(sep string, v any) string {
+	items, ok := v.([]any)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	parts := make([]string, len(items))
+	for i, it := range items {
+		parts[i] = fmt.Sprint(it)
+	}
+	return strings.Join(parts, sep)
+}
+
+// templateDefault returns def when v is nil or an empty string.
+func templateDefault(def, v any) any {
+	if v == nil {
+		return def
+	}
+	if s, ok := v.(string); ok && s == "" {
+		return def
+	}
+	return v
+}
+
+func toTime(v any) (time.Time, bool) {
+	var s string
+	switch vv := v.(type) {
+	case time.Time:
+		return vv, true
+	case string:
+		s = vv
+	case json.Number:
+		s = vv.String()
+	case float64:
+		s = strconv.FormatFloat(vv, 'f', -1, 64)
+	case int:
+		s = strconv.Itoa(vv)
+	case int64:
+		s = strconv.FormatInt(vv, 10)
+	default:
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if n > 1e12 {
+		return time.UnixMilli(n).UTC(), true
+	}
+	return time.Unix(n, 0).UTC(), true
+}
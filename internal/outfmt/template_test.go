@@ -0,0 +1,33 @@
+package outfmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	old := templateNow
+	templateNow = func() time.Time { return time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { templateNow = old })
+
+	tmpl, err := ParseTemplate(`{{date "2006-01-02" .ts}}|{{date "2006-01-02" .unix}}|{{ago .ts}}|{{truncate 5 .name}}|{{json .tags}}|{{join "," .tags}}|{{default "-" .missing}}|{{upper .name}}`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var b bytes.Buffer
+	err = PrintTemplate(&b, tmpl, map[string]any{
+		"ts":   "2026-01-01T10:00:00Z",
+		"unix": json.Number("1767225600000"),
+		"name": "Launch day",
+		"tags": []any{"a", "b"},
+	})
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	want := `2026-01-01|2026-01-01|1d|Laun…|["a","b"]|a,b|-|LAUNCH DAY`
+	if b.String() != want {
+		t.Fatalf("got %q want %q", b.String(), want)
+	}
+}
@@ -0,0 +1,46 @@
+package outfmt
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// PrintYAML writes v as a YAML document. v should be JSON-shaped (maps, slices,
+// scalars); json.Number values are emitted as plain YAML numbers.
+func PrintYAML(w io.Writer, v any) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(yamlValue(v)); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// yamlValue replaces json.Number (a string type yaml would quote) with numeric scalar nodes.
+func yamlValue(v any) any {
+	switch vv := v.(type) {
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(vv.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: vv.String()}
+	case map[string]any:
+		out := make(map[string]any, len(vv))
+		for k, x := range vv {
+			out[k] = yamlValue(x)
+		}
+		return out
+	case []any:
+		out := make([]any, len(vv))
+		for i, x := range vv {
+			out[i] = yamlValue(x)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,24 @@
+package outfmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestPrintYAML_NumbersStayNumeric(t *testing.T) {
+	var b bytes.Buffer
+	v := map[string]any{
+		"id":    json.Number("12345678901234567890"),
+		"limit": json.Number("50"),
+		"rate":  json.Number("0.5"),
+		"tags":  []any{"a", "b"},
+	}
+	if err := PrintYAML(&b, v); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	want := "id: 12345678901234567890\nlimit: 50\nrate: 0.5\ntags:\n  - a\n  - b\n"
+	if b.String() != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,9 @@
+// Package sdkbridge lets the CLI build the public instantly SDK on a fully
+// configured internal api.Client without exposing that type in the SDK's API.
+package sdkbridge
+
+import "github.com/salmonumbrella/instantly-cli/internal/api"
+
+// NewClient returns an *instantly.Client using c for transport. The instantly
+// package sets it in its init.
+var NewClient func(c *api.Client) any